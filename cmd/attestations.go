@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jetstack/tally/internal/bom"
+	"github.com/jetstack/tally/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var attestationsCmd = &cobra.Command{
+	Use:   "attestations <oci-layout|image.tar>...",
+	Short: "Finds OpenSSF Scorecard scores for packages in the SBOM attestations attached to an image.",
+	Long: `Finds OpenSSF Scorecard scores for packages in the SBOM attestations attached to an image.
+
+The image must be a local OCI image layout directory or a tarball of one, like those created by 'docker save'. SBOM attestations are found in the attestation manifests in the image index, like those created by BuildKit.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var pkgRepos []*types.PackageRepositories
+		for _, path := range args {
+			ociPkgRepos, err := bom.PackageRepositoriesFromOCI(path)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			bom.SetSource(ociPkgRepos, path)
+			pkgRepos = bom.MergePackageRepositories(pkgRepos, ociPkgRepos...)
+		}
+
+		return runTally(context.Background(), pkgRepos)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(attestationsCmd)
+}
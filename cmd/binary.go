@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jetstack/tally/internal/bom"
+	"github.com/jetstack/tally/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var binaryCmd = &cobra.Command{
+	Use:   "binary <path>...",
+	Short: "Finds OpenSSF Scorecard scores for the modules compiled into Go binaries.",
+	Long:  `Finds OpenSSF Scorecard scores for the modules compiled into Go binaries, using the build information embedded in each binary.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var pkgRepos []*types.PackageRepositories
+		for _, path := range args {
+			binPkgRepos, err := packageRepositoriesFromGoBinary(path)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			bom.SetSource(binPkgRepos, path)
+			pkgRepos = bom.MergePackageRepositories(pkgRepos, binPkgRepos...)
+		}
+
+		return runTally(context.Background(), pkgRepos)
+	},
+}
+
+func packageRepositoriesFromGoBinary(path string) ([]*types.PackageRepositories, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := bom.ParseGoBinary(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return bom.PackageRepositoriesFromGoBuildInfo(info)
+}
+
+func init() {
+	rootCmd.AddCommand(binaryCmd)
+}
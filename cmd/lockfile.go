@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jetstack/tally/internal/bom"
+	"github.com/jetstack/tally/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var lockfileCmd = &cobra.Command{
+	Use:   "lockfile <path>...",
+	Short: "Finds OpenSSF Scorecard scores for the packages in lockfiles.",
+	Long: fmt.Sprintf(`Finds OpenSSF Scorecard scores for the packages in lockfiles.
+
+The type of each lockfile is determined by its name. When a directory is given, all the supported lockfiles in that directory are scanned.
+
+Supported lockfiles: %s`, strings.Join(bom.Lockfiles, ", ")),
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var pkgRepos []*types.PackageRepositories
+		for _, path := range args {
+			lockfilePkgRepos, err := bom.PackageRepositoriesFromLockfile(path)
+			if err != nil {
+				return err
+			}
+			bom.SetSource(lockfilePkgRepos, path)
+			pkgRepos = bom.MergePackageRepositories(pkgRepos, lockfilePkgRepos...)
+		}
+
+		return runTally(context.Background(), pkgRepos)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lockfileCmd)
+}
@@ -2,132 +2,392 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/jetstack/tally/internal/bom"
 	"github.com/jetstack/tally/internal/cache"
 	"github.com/jetstack/tally/internal/output"
+	"github.com/jetstack/tally/internal/resolver"
 	"github.com/jetstack/tally/internal/scorecard"
 	scorecardapi "github.com/jetstack/tally/internal/scorecard/api"
 	"github.com/jetstack/tally/internal/tally"
+	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
 	"github.com/spf13/cobra"
 )
 
 type rootOptions struct {
-	All            bool
-	API            bool
-	APITimeout     time.Duration
-	APIURL         string
-	Cache          bool
-	CacheDir       string
-	CacheDuration  time.Duration
-	FailOn         float64Flag
-	Format         string
-	GenerateScores bool
-	Output         string
+	All                bool
+	API                bool
+	APITimeout         time.Duration
+	APIURL             string
+	Cache              bool
+	CacheDir           string
+	CacheDuration      time.Duration
+	CratesURL          string
+	ExplainPaths       bool
+	FailOn             float64Flag
+	Format             string
+	GenerateScores     bool
+	GoProxyURL         string
+	IgnoreFile         string
+	IgnoreNames        []string
+	IgnoreRepositories []string
+	IgnoreScopes       []string
+	IgnoreTypes        []string
+	Mappings           string
+	MavenURL           string
+	NPMURL             string
+	Output             string
+	PyPIURL            string
+	Resolve            bool
+	ResolveTimeout     time.Duration
+	RubyGemsURL        string
+	VCSHosts           map[string]string
 }
 
 var ro rootOptions
 
 var rootCmd = &cobra.Command{
-	Use:   "tally",
+	Use:   "tally <bom>...",
 	Short: "Finds OpenSSF Scorecard scores for packages in a Software Bill of Materials.",
 	Long:  `Finds OpenSSF Scorecard scores for packages in a Software Bill of Materials.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		ctx := context.Background()
-
-		// Configure the output writer
-		out, err := output.NewOutput(
-			output.Format(ro.Output),
-			output.WithAll(ro.All),
-		)
-		if err != nil {
-			return fmt.Errorf("creating output writer: %w", err)
+	Args:  cobra.MinimumNArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Configure the self-hosted VCS hosts before any urls are
+		// parsed
+		for host, platform := range ro.VCSHosts {
+			if err := vcs_url.AddHost(host, vcs_url.Platform(platform)); err != nil {
+				return fmt.Errorf("configuring VCS host %s: %w", host, err)
+			}
 		}
 
-		// Get packages from the BOM
-		var r io.ReadCloser
-		if args[0] == "-" {
-			r = os.Stdin
-		} else {
-			r, err = os.Open(args[0])
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get packages from each of the BOMs and merge them together
+		var pkgRepos []*types.PackageRepositories
+		for _, arg := range args {
+			bomPkgRepos, err := packageRepositoriesFromBOMArg(arg, bom.Format(ro.Format))
 			if err != nil {
 				return err
 			}
-			defer r.Close()
+			pkgRepos = bom.MergePackageRepositories(pkgRepos, bomPkgRepos...)
+		}
+
+		return runTally(context.Background(), pkgRepos)
+	},
+}
+
+// packageRepositoriesFromBOMArg discovers packages in the BOM at the given
+// path. If the path is a directory then every BOM in the directory is read.
+func packageRepositoriesFromBOMArg(path string, format bom.Format) ([]*types.PackageRepositories, error) {
+	if path == "-" {
+		return packageRepositoriesFromBOMReader(os.Stdin, "stdin", format)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return packageRepositoriesFromBOMFile(path, format)
+	}
+
+	var pkgRepos []*types.PackageRepositories
+	if err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Hidden directories, like .git, and installed dependencies
+		// don't contain the BOMs we're looking for
+		if d.IsDir() && p != path && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+			return fs.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil
 		}
-		pkgRepos, err := bom.PackageRepositoriesFromBOM(r, bom.Format(ro.Format))
+		bomPkgRepos, err := packageRepositoriesFromBOMDirFile(p, format)
 		if err != nil {
 			return err
 		}
+		pkgRepos = bom.MergePackageRepositories(pkgRepos, bomPkgRepos...)
 
-		var scorecardClients []scorecard.Client
+		return nil
+	}); err != nil {
+		return nil, err
+	}
 
-		// Fetch scores from the API
-		if ro.API {
-			apiClient, err := scorecardapi.NewClient(ro.APIURL)
-			if err != nil {
-				return fmt.Errorf("configuring API client: %w", err)
-			}
-			scorecardClients = append(scorecardClients, apiClient)
+	return pkgRepos, nil
+}
+
+func packageRepositoriesFromBOMFile(path string, format bom.Format) ([]*types.PackageRepositories, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return packageRepositoriesFromBOMReader(f, path, format)
+}
+
+// packageRepositoriesFromBOMDirFile reads a file found in a directory of BOMs.
+// Directories may contain files that aren't BOMs, or aren't BOMs in the format
+// that has been set, so those are skipped with a warning. Files that are BOMs
+// in the expected format must parse.
+func packageRepositoriesFromBOMDirFile(path string, format bom.Format) ([]*types.PackageRepositories, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	detected, r, err := bom.DetectFormat(f)
+	if errors.Is(err, bom.ErrUnknownFormat) {
+		fmt.Fprintf(os.Stderr, "Skipping %s: %s\n", path, err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if format != bom.FormatAuto && detected != format {
+		fmt.Fprintf(os.Stderr, "Skipping %s: detected BOM format %s doesn't match %s\n", path, detected, format)
+		return nil, nil
+	}
+	fmt.Fprintf(os.Stderr, "Detected BOM format for %s: %s\n", path, detected)
+
+	return packageRepositoriesFromBOMReader(r, path, detected)
+}
+
+func packageRepositoriesFromBOMReader(r io.Reader, source string, format bom.Format) ([]*types.PackageRepositories, error) {
+	// Detect the format of the BOM unless it has been set explicitly
+	if format == bom.FormatAuto {
+		var err error
+		format, r, err = bom.DetectFormat(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
 		}
+		fmt.Fprintf(os.Stderr, "Detected BOM format for %s: %s\n", source, format)
+	}
+	pkgRepos, err := bom.PackageRepositoriesFromBOM(r, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	bom.SetSource(pkgRepos, source)
 
-		// Generate scores with the scorecard client
-		if ro.GenerateScores {
-			sc, err := scorecard.NewScorecardClient()
-			if err != nil {
-				return fmt.Errorf("configuring scorecard client: %w", err)
-			}
-			scorecardClients = append(scorecardClients, sc)
+	return pkgRepos, nil
+}
+
+// runTally finds scores for the packages, writes the report and exits
+// non-zero if any of the scores are below the --fail-on threshold
+func runTally(ctx context.Context, pkgRepos []*types.PackageRepositories) error {
+	// Configure the output writer
+	out, err := output.NewOutput(
+		output.Format(ro.Output),
+		output.WithAll(ro.All),
+		output.WithPaths(ro.ExplainPaths),
+	)
+	if err != nil {
+		return fmt.Errorf("creating output writer: %w", err)
+	}
+
+	// Pin packages to the repositories in the mappings file. Overridden
+	// packages aren't looked up in the package registries.
+	unpinned := pkgRepos
+	if ro.Mappings != "" {
+		overrides, err := loadOverrides(ro.Mappings)
+		if err != nil {
+			return err
 		}
+		unpinned = overrides.Apply(pkgRepos...)
+	}
 
-		// At least one scorecard client must be configured
-		if len(scorecardClients) == 0 {
-			fmt.Fprintf(os.Stderr, "Error: no scorecard clients configured. At least one of --api or --generate must be set.\n")
-			os.Exit(1)
+	// Drop the packages that are ignored, so that they aren't looked up in
+	// the package registries or scored
+	ignore, err := newIgnore()
+	if err != nil {
+		return err
+	}
+	pkgRepos, excluded := ignore.Filter(pkgRepos...)
+	unpinned, _ = ignore.Filter(unpinned...)
+
+	// Find repositories for packages that don't have any by looking up
+	// their metadata in the package registries
+	if ro.Resolve {
+		resolvers, err := newResolvers()
+		if err != nil {
+			return fmt.Errorf("configuring resolvers: %w", err)
+		}
+		if err := resolver.Resolve(ctx, os.Stderr, resolvers, unpinned...); err != nil {
+			return fmt.Errorf("resolving repositories: %w", err)
 		}
 
-		// Cache scorecard results locally to speed up subsequent runs
-		if ro.Cache {
-			dbCache, err := cache.NewSqliteCache(ro.CacheDir, cache.WithDuration(ro.CacheDuration))
-			if err != nil {
-				return fmt.Errorf("creating cache: %w", err)
-			}
+		// The resolved repositories may be ignored
+		var resolvedExcluded []*types.PackageRepositories
+		pkgRepos, resolvedExcluded = ignore.Filter(pkgRepos...)
+		excluded = append(excluded, resolvedExcluded...)
+	}
 
-			// Wrap our clients with the cache
-			for i, client := range scorecardClients {
-				scorecardClients[i] = cache.NewScorecardClient(dbCache, client)
-			}
+	var scorecardClients []scorecard.Client
+
+	// Fetch scores from the API
+	if ro.API {
+		apiClient, err := scorecardapi.NewClient(ro.APIURL)
+		if err != nil {
+			return fmt.Errorf("configuring API client: %w", err)
 		}
+		scorecardClients = append(scorecardClients, apiClient)
+	}
 
-		// Run tally
-		report, err := tally.Run(ctx, os.Stderr, scorecardClients, pkgRepos...)
+	// Generate scores with the scorecard client
+	if ro.GenerateScores {
+		sc, err := scorecard.NewScorecardClient()
 		if err != nil {
-			return fmt.Errorf("getting results: %w", err)
+			return fmt.Errorf("configuring scorecard client: %w", err)
 		}
+		scorecardClients = append(scorecardClients, sc)
+	}
 
-		// Write report to output
-		if err := out.WriteReport(os.Stdout, *report); err != nil {
-			os.Exit(1)
+	// At least one scorecard client must be configured
+	if len(scorecardClients) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no scorecard clients configured. At least one of --api or --generate must be set.\n")
+		os.Exit(1)
+	}
+
+	// Cache scorecard results locally to speed up subsequent runs
+	if ro.Cache {
+		dbCache, err := cache.NewSqliteCache(ro.CacheDir, cache.WithDuration(ro.CacheDuration))
+		if err != nil {
+			return fmt.Errorf("creating cache: %w", err)
+		}
+
+		// Wrap our clients with the cache
+		for i, client := range scorecardClients {
+			scorecardClients[i] = cache.NewScorecardClient(dbCache, client)
 		}
+	}
+
+	// Run tally
+	report, err := tally.Run(ctx, os.Stderr, scorecardClients, pkgRepos...)
+	if err != nil {
+		return fmt.Errorf("getting results: %w", err)
+	}
+	var unsupported int
+	for _, result := range report.Results {
+		if result.Unsupported {
+			unsupported++
+		}
+	}
+	if unsupported > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d repositories are hosted on platforms that the configured scorecard clients don't support\n", unsupported)
+	}
+	for _, pkgRepo := range excluded {
+		report.Excluded = append(report.Excluded, *pkgRepo)
+	}
 
-		// Exit 1 if there is a score <= o.FailOn
-		if ro.FailOn.Value != nil {
-			for _, result := range report.Results {
-				if result.Result == nil || result.Result.Score > *ro.FailOn.Value {
-					continue
-				}
-				fmt.Fprintf(os.Stderr, "Error: found scores <= to %0.2f\n", *ro.FailOn.Value)
-				os.Exit(1)
+	// Write report to output
+	if err := out.WriteReport(os.Stdout, *report); err != nil {
+		os.Exit(1)
+	}
+
+	// Exit 1 if there is a score <= o.FailOn
+	if ro.FailOn.Value != nil {
+		for _, result := range report.Results {
+			if result.Result == nil || result.Result.Score > *ro.FailOn.Value {
+				continue
 			}
+			fmt.Fprintf(os.Stderr, "Error: found scores <= to %0.2f\n", *ro.FailOn.Value)
+			os.Exit(1)
 		}
+	}
 
-		return nil
-	},
+	return nil
+}
+
+// loadOverrides reads the package to repository overrides from the file at
+// the given path
+func loadOverrides(path string) (*bom.Overrides, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	overrides, err := bom.ParseOverrides(f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return overrides, nil
+}
+
+// newIgnore returns the ignore rules from the --ignore-file and the
+// --ignore-* flags
+func newIgnore() (*bom.Ignore, error) {
+	ignore := &bom.Ignore{}
+	if ro.IgnoreFile != "" {
+		f, err := os.Open(ro.IgnoreFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		ignore, err = bom.ParseIgnore(f)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", ro.IgnoreFile, err)
+		}
+	}
+
+	var rules []bom.IgnoreRule
+	for _, t := range ro.IgnoreTypes {
+		rules = append(rules, bom.IgnoreRule{Type: t})
+	}
+	for _, name := range ro.IgnoreNames {
+		rules = append(rules, bom.IgnoreRule{Name: name})
+	}
+	for _, repo := range ro.IgnoreRepositories {
+		rules = append(rules, bom.IgnoreRule{Repository: repo})
+	}
+	for _, scope := range ro.IgnoreScopes {
+		rules = append(rules, bom.IgnoreRule{Scope: scope})
+	}
+	if err := ignore.Add(rules...); err != nil {
+		return nil, fmt.Errorf("configuring ignore rules: %w", err)
+	}
+
+	return ignore, nil
+}
+
+// newResolvers returns a resolver for each of the supported package
+// registries
+func newResolvers() ([]resolver.Resolver, error) {
+	var resolvers []resolver.Resolver
+	for _, r := range []struct {
+		url         string
+		newResolver func(string, ...resolver.Option) (resolver.Resolver, error)
+	}{
+		{ro.CratesURL, resolver.NewCratesResolver},
+		{ro.GoProxyURL, resolver.NewGoResolver},
+		{ro.MavenURL, resolver.NewMavenResolver},
+		{ro.NPMURL, resolver.NewNPMResolver},
+		{ro.PyPIURL, resolver.NewPyPIResolver},
+		{ro.RubyGemsURL, resolver.NewRubyGemsResolver},
+	} {
+		res, err := r.newResolver(r.url, resolver.WithTimeout(ro.ResolveTimeout))
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, res)
+	}
+
+	return resolvers, nil
 }
 
 func Execute() {
@@ -138,15 +398,31 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.Flags().StringVarP(&ro.Format, "format", "f", string(bom.FormatCycloneDXJSON), fmt.Sprintf("BOM format, options=%s", bom.Formats))
-	rootCmd.Flags().BoolVarP(&ro.All, "all", "a", false, "print all packages, even those without a scorecard score")
-	rootCmd.Flags().BoolVar(&ro.API, "api", true, "fetch scores from the Scorecard API")
-	rootCmd.Flags().DurationVar(&ro.APITimeout, "api-timeout", scorecardapi.DefaultTimeout, "timeout for requests to scorecard API")
-	rootCmd.Flags().StringVar(&ro.APIURL, "api-url", scorecardapi.DefaultURL, "scorecard API URL")
-	rootCmd.Flags().StringVarP(&ro.Output, "output", "o", "short", fmt.Sprintf("output format, options=%s", output.Formats))
-	rootCmd.Flags().BoolVarP(&ro.GenerateScores, "generate", "g", false, "generate scores for repositories that aren't in the database. The GITHUB_TOKEN environment variable must be set.")
-	rootCmd.Flags().BoolVar(&ro.Cache, "cache", true, "cache scores locally")
-	rootCmd.Flags().StringVar(&ro.CacheDir, "cache-dir", "", "directory to cache scores in, defaults to $HOME/.cache/tally/cache on most systems")
-	rootCmd.Flags().DurationVar(&ro.CacheDuration, "cache-duration", 7*(24*time.Hour), "how long to cache scores for; defaults to 7 days")
-	rootCmd.Flags().Var(&ro.FailOn, "fail-on", "fail if a package is found with a score <= to the given value")
+	rootCmd.Flags().StringVarP(&ro.Format, "format", "f", string(bom.FormatAuto), fmt.Sprintf("BOM format, options=%s", bom.Formats))
+	rootCmd.PersistentFlags().BoolVarP(&ro.All, "all", "a", false, "print all packages, even those without a scorecard score")
+	rootCmd.PersistentFlags().BoolVar(&ro.API, "api", true, "fetch scores from the Scorecard API")
+	rootCmd.PersistentFlags().DurationVar(&ro.APITimeout, "api-timeout", scorecardapi.DefaultTimeout, "timeout for requests to scorecard API")
+	rootCmd.PersistentFlags().StringVar(&ro.APIURL, "api-url", scorecardapi.DefaultURL, "scorecard API URL")
+	rootCmd.PersistentFlags().StringVarP(&ro.Output, "output", "o", "short", fmt.Sprintf("output format, options=%s", output.Formats))
+	rootCmd.PersistentFlags().BoolVarP(&ro.GenerateScores, "generate", "g", false, "generate scores for repositories that aren't in the database. The GITHUB_TOKEN environment variable must be set.")
+	rootCmd.PersistentFlags().BoolVar(&ro.Cache, "cache", true, "cache scores locally")
+	rootCmd.PersistentFlags().StringVar(&ro.CacheDir, "cache-dir", "", "directory to cache scores in, defaults to $HOME/.cache/tally/cache on most systems")
+	rootCmd.PersistentFlags().DurationVar(&ro.CacheDuration, "cache-duration", 7*(24*time.Hour), "how long to cache scores for; defaults to 7 days")
+	rootCmd.PersistentFlags().BoolVar(&ro.ExplainPaths, "explain-paths", false, "include the shortest dependency path to each package in the wide and json outputs")
+	rootCmd.PersistentFlags().Var(&ro.FailOn, "fail-on", "fail if a package is found with a score <= to the given value")
+	rootCmd.PersistentFlags().StringVar(&ro.IgnoreFile, "ignore-file", "", "YAML or JSON file of rules that exclude packages from tally")
+	rootCmd.PersistentFlags().StringSliceVar(&ro.IgnoreTypes, "ignore-type", []string{}, "exclude packages of the given purl type")
+	rootCmd.PersistentFlags().StringSliceVar(&ro.IgnoreNames, "ignore-name", []string{}, "exclude packages with names that match the given glob")
+	rootCmd.PersistentFlags().StringSliceVar(&ro.IgnoreRepositories, "ignore-repository", []string{}, "exclude packages with repositories that match the given glob")
+	rootCmd.PersistentFlags().StringSliceVar(&ro.IgnoreScopes, "ignore-scope", []string{}, "exclude CycloneDX components with the given scope")
+	rootCmd.PersistentFlags().StringVar(&ro.Mappings, "mappings", "", "YAML or JSON file that pins packages to repositories, overriding the repositories found in the BOM")
+	rootCmd.PersistentFlags().BoolVar(&ro.Resolve, "resolve", false, "look up the repositories of packages that don't have any in their package registry")
+	rootCmd.PersistentFlags().DurationVar(&ro.ResolveTimeout, "resolve-timeout", resolver.DefaultTimeout, "timeout for requests to package registries, used by --resolve")
+	rootCmd.PersistentFlags().StringVar(&ro.CratesURL, "crates-url", resolver.DefaultCratesURL, "crates.io API URL, used by --resolve")
+	rootCmd.PersistentFlags().StringVar(&ro.GoProxyURL, "goproxy-url", "", "GOPROXY URL to check for the origin of Go modules before using ?go-get=1 discovery, used by --resolve")
+	rootCmd.PersistentFlags().StringVar(&ro.MavenURL, "maven-url", resolver.DefaultMavenURL, "maven repository URL, used by --resolve")
+	rootCmd.PersistentFlags().StringVar(&ro.NPMURL, "npm-url", resolver.DefaultNPMURL, "npm registry URL, used by --resolve")
+	rootCmd.PersistentFlags().StringVar(&ro.PyPIURL, "pypi-url", resolver.DefaultPyPIURL, "PyPI JSON API URL, used by --resolve")
+	rootCmd.PersistentFlags().StringVar(&ro.RubyGemsURL, "rubygems-url", resolver.DefaultRubyGemsURL, "RubyGems API URL, used by --resolve")
+	rootCmd.PersistentFlags().StringToStringVar(&ro.VCSHosts, "vcs-host", map[string]string{}, fmt.Sprintf("self-hosted VCS host and its platform, i.e gitlab.example.com=gitlab. Platforms=%s", vcs_url.Platforms))
 }
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/bom"
+)
+
+func TestPackageRepositoriesFromBOMArg(t *testing.T) {
+	cdx, err := os.ReadFile(filepath.Join("..", "internal", "bom", "testdata", "cdx.json"))
+	if err != nil {
+		t.Fatalf("unexpected error reading BOM: %s", err)
+	}
+
+	testCases := map[string]struct {
+		files     map[string]string
+		format    bom.Format
+		wantNames []string
+		wantErr   bool
+	}{
+		"files that aren't BOMs are skipped": {
+			files: map[string]string{
+				"bom.json":  string(cdx),
+				"README.md": "# Not a BOM\n",
+			},
+			format: bom.FormatAuto,
+			wantNames: []string{
+				"golang/foo/bar",
+				"maven/org.hdrhistogram/HdrHistogram",
+				"deb/debian/adduser",
+			},
+		},
+		"BOMs in a different format to the one that has been set are skipped": {
+			files: map[string]string{
+				"bom.json":  string(cdx),
+				"README.md": "# Not a BOM\n",
+			},
+			format: bom.FormatSPDXJSON,
+		},
+		"BOMs that fail to parse return an error": {
+			files: map[string]string{
+				"bom.json":         string(cdx),
+				"corrupt.cdx.json": `{"bomFormat": "CycloneDX", "components": "foo"}`,
+			},
+			format:  bom.FormatAuto,
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, data := range tc.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+					t.Fatalf("unexpected error writing file: %s", err)
+				}
+			}
+
+			pkgRepos, err := packageRepositoriesFromBOMArg(dir, tc.format)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var gotNames []string
+			for _, pkgRepo := range pkgRepos {
+				gotNames = append(gotNames, pkgRepo.Type+"/"+pkgRepo.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+		})
+	}
+}
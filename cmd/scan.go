@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jetstack/tally/internal/bom"
+	"github.com/spf13/cobra"
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan <dir|image.tar|oci-layout>",
+	Short: "Generates an SBOM with syft and finds OpenSSF Scorecard scores for the packages in it.",
+	Long:  `Generates an SBOM for a local directory, image tarball or OCI layout with syft and finds OpenSSF Scorecard scores for the packages in it.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, err := bom.GenerateSyftBOM(os.Stderr, args[0])
+		if err != nil {
+			return fmt.Errorf("generating BOM for %s: %w", args[0], err)
+		}
+		pkgRepos, err := bom.PackageRepositoriesFromSyftBOM(doc)
+		if err != nil {
+			return err
+		}
+
+		return runTally(context.Background(), pkgRepos)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+}
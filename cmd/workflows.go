@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/jetstack/tally/internal/bom"
+	"github.com/jetstack/tally/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var workflowsCmd = &cobra.Command{
+	Use:   "workflows <path>...",
+	Short: "Finds OpenSSF Scorecard scores for the actions used by GitHub Actions workflows.",
+	Long: `Finds OpenSSF Scorecard scores for the actions used by GitHub Actions workflows.
+
+Each path can be a workflow file, a .github/workflows directory or the root of a repository, in which case the workflows in .github/workflows are used. Actions and reusable workflows are reported as github-actions packages. Docker images and actions in the same repository are skipped.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var pkgRepos []*types.PackageRepositories
+		for _, path := range args {
+			workflowPkgRepos, err := bom.PackageRepositoriesFromWorkflows(path)
+			if err != nil {
+				return err
+			}
+			bom.SetSource(workflowPkgRepos, path)
+			pkgRepos = bom.MergePackageRepositories(pkgRepos, workflowPkgRepos...)
+		}
+
+		return runTally(context.Background(), pkgRepos)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(workflowsCmd)
+}
@@ -12,22 +12,36 @@ import (
 type Format string
 
 const (
+	FormatAuto          Format = "auto"
 	FormatCycloneDXJSON Format = "cyclonedx-json"
 	FormatCycloneDXXML  Format = "cyclonedx-xml"
 	FormatSyftJSON      Format = "syft-json"
+	FormatSPDXJSON      Format = "spdx-json"
+	FormatSPDXTagValue  Format = "spdx-tag-value"
+	FormatInToto        Format = "in-toto"
 )
 
 // Formats are all the supported SBOM formats
 var Formats = []Format{
+	FormatAuto,
 	FormatCycloneDXJSON,
 	FormatCycloneDXXML,
 	FormatSyftJSON,
+	FormatSPDXJSON,
+	FormatSPDXTagValue,
+	FormatInToto,
 }
 
 // PackageRepositoriesFromBOM discovers packages and their associated
 // repositories in an SBOM
 func PackageRepositoriesFromBOM(r io.Reader, format Format) ([]*types.PackageRepositories, error) {
 	switch format {
+	case FormatAuto:
+		detected, r, err := DetectFormat(r)
+		if err != nil {
+			return nil, err
+		}
+		return PackageRepositoriesFromBOM(r, detected)
 	case FormatCycloneDXJSON:
 		bom, err := ParseCycloneDXBOM(r, cyclonedx.BOMFileFormatJSON)
 		if err != nil {
@@ -46,7 +60,47 @@ func PackageRepositoriesFromBOM(r io.Reader, format Format) ([]*types.PackageRep
 			return nil, fmt.Errorf("parsing BOM in syft-json format: %w", err)
 		}
 		return PackageRepositoriesFromSyftBOM(bom)
+	case FormatSPDXJSON:
+		bom, err := ParseSPDXJSONBOM(r)
+		if err != nil {
+			return nil, fmt.Errorf("parsing BOM in spdx-json format: %w", err)
+		}
+		return PackageRepositoriesFromSPDXBOM(bom)
+	case FormatSPDXTagValue:
+		bom, err := ParseSPDXTagValueBOM(r)
+		if err != nil {
+			return nil, fmt.Errorf("parsing BOM in spdx-tag-value format: %w", err)
+		}
+		return PackageRepositoriesFromSPDXBOM(bom)
+	case FormatInToto:
+		predicateFormat, r, err := UnwrapAttestation(r)
+		if err != nil {
+			return nil, fmt.Errorf("parsing BOM in in-toto format: %w", err)
+		}
+		if predicateFormat == FormatInToto {
+			return nil, fmt.Errorf("parsing BOM in in-toto format: predicate is itself an attestation")
+		}
+		return PackageRepositoriesFromBOM(r, predicateFormat)
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
 }
+
+// MergePackageRepositories merges packages into an existing list, combining
+// the repositories of any packages that are already present
+func MergePackageRepositories(pkgRepos []*types.PackageRepositories, others ...*types.PackageRepositories) []*types.PackageRepositories {
+	for _, pkgRepo := range others {
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+	}
+
+	return pkgRepos
+}
+
+// SetSource records the input that the packages were discovered in, so that
+// packages can be traced back to their SBOM once the results of several inputs
+// have been merged
+func SetSource(pkgRepos []*types.PackageRepositories, source string) {
+	for _, pkgRepo := range pkgRepos {
+		pkgRepo.AddSources(source)
+	}
+}
@@ -0,0 +1,102 @@
+package bom
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestMergePackageRepositories(t *testing.T) {
+	first := []*types.PackageRepositories{
+		{
+			Package: types.Package{
+				Type: "golang",
+				Name: "github.com/foo/bar",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		{
+			Package: types.Package{
+				Type: "npm",
+				Name: "foo",
+			},
+		},
+	}
+	SetSource(first, "first.json")
+
+	second := []*types.PackageRepositories{
+		{
+			Package: types.Package{
+				Type: "golang",
+				Name: "github.com/foo/bar",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		{
+			Package: types.Package{
+				Type: "npm",
+				Name: "foo",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/foo",
+				},
+			},
+		},
+		{
+			Package: types.Package{
+				Type: "pypi",
+				Name: "baz",
+			},
+		},
+	}
+	SetSource(second, "second.json")
+
+	wantPackages := []*types.PackageRepositories{
+		{
+			Package: types.Package{
+				Type:    "golang",
+				Name:    "github.com/foo/bar",
+				Sources: []string{"first.json", "second.json"},
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		{
+			Package: types.Package{
+				Type:    "npm",
+				Name:    "foo",
+				Sources: []string{"first.json", "second.json"},
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/foo",
+				},
+			},
+		},
+		{
+			Package: types.Package{
+				Type:    "pypi",
+				Name:    "baz",
+				Sources: []string{"second.json"},
+			},
+		},
+	}
+
+	gotPackages := MergePackageRepositories(MergePackageRepositories(nil, first...), second...)
+	if diff := cmp.Diff(wantPackages, gotPackages); diff != "" {
+		t.Errorf("unexpected packages:\n%s", diff)
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"io"
 
 	"github.com/CycloneDX/cyclonedx-go"
-	github_url "github.com/jetstack/tally/internal/github-url"
 	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
 )
 
 // ParseCycloneDXBOM parses a cyclonedx BOM in the specified format
@@ -22,16 +22,26 @@ func ParseCycloneDXBOM(r io.Reader, format cyclonedx.BOMFileFormat) (*cyclonedx.
 // PackageRepositoriesFromCycloneDXBOM extracts packages from a cyclonedx BOM
 func PackageRepositoriesFromCycloneDXBOM(bom *cyclonedx.BOM) ([]*types.PackageRepositories, error) {
 	var pkgRepos []*types.PackageRepositories
+	refNames := map[string]string{}
+	refPkgs := map[string]types.Package{}
 	if err := foreachComponentIn(
 		bom,
-		func(component cyclonedx.Component) error {
-			pkgRepo, err := packageRepositoriesFromCycloneDXComponent(component)
+		func(component cyclonedx.Component, subject bool) error {
+			if component.BOMRef != "" {
+				refNames[component.BOMRef] = component.Name
+			}
+
+			pkgRepo, err := packageRepositoriesFromCycloneDXComponent(component, subject)
 			if err != nil {
 				return err
 			}
 			if pkgRepo == nil {
 				return nil
 			}
+			if component.BOMRef != "" {
+				refPkgs[component.BOMRef] = pkgRepo.Package
+				refNames[component.BOMRef] = pkgRepo.Name
+			}
 
 			pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
 
@@ -42,24 +52,63 @@ func PackageRepositoriesFromCycloneDXBOM(bom *cyclonedx.BOM) ([]*types.PackageRe
 		return nil, fmt.Errorf("finding packages in BOM: %w", err)
 	}
 
+	var root string
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		root = bom.Metadata.Component.BOMRef
+	}
+	setDependencies(cycloneDXDependencyGraph(bom), root, refNames, refPkgs, pkgRepos)
+
 	return pkgRepos, nil
 }
 
-func packageRepositoriesFromCycloneDXComponent(component cyclonedx.Component) (*types.PackageRepositories, error) {
-	if component.PackageURL == "" {
-		return nil, nil
+func cycloneDXDependencyGraph(bom *cyclonedx.BOM) *dependencyGraph {
+	graph := newDependencyGraph()
+	if bom.Dependencies == nil {
+		return graph
 	}
-	pkgRepo, err := packageRepositoriesFromPurl(component.PackageURL)
-	if err != nil {
-		return nil, err
+	for _, dep := range *bom.Dependencies {
+		if dep.Dependencies == nil {
+			continue
+		}
+		for _, ref := range *dep.Dependencies {
+			graph.addEdge(dep.Ref, ref)
+		}
 	}
+
+	return graph
+}
+
+// packageRepositoriesFromCycloneDXComponent returns the package for a
+// component. The subject is the component the BOM describes (i.e an image),
+// which is only a package when it has a purl.
+func packageRepositoriesFromCycloneDXComponent(component cyclonedx.Component, subject bool) (*types.PackageRepositories, error) {
+	var pkgRepo *types.PackageRepositories
+	if component.PackageURL != "" {
+		var err error
+		pkgRepo, err = packageRepositoriesFromPurl(component.PackageURL)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Components without a purl are identified by their group,
+		// name and CPE. Operating systems, files, containers and
+		// the like aren't packages that have a repository.
+		if subject || !isCycloneDXPackageType(component.Type) {
+			return nil, nil
+		}
+		pkgRepo = packageRepositoriesFromIdentity(component.Group, component.Name, component.Version, component.CPE)
+		if pkgRepo == nil {
+			return nil, nil
+		}
+	}
+	pkgRepo.Scope = string(component.Scope)
 	if component.ExternalReferences == nil {
 		return pkgRepo, nil
 	}
 	for _, ref := range *component.ExternalReferences {
 		switch ref.Type {
 		case cyclonedx.ERTypeVCS, cyclonedx.ERTypeDistribution, cyclonedx.ERTypeWebsite:
-			repo := github_url.ToRepository(ref.URL)
+			repo := vcs_url.ToRepository(ref.URL)
 			if repo == nil {
 				continue
 			}
@@ -70,20 +119,41 @@ func packageRepositoriesFromCycloneDXComponent(component cyclonedx.Component) (*
 	return pkgRepo, nil
 }
 
-func foreachComponentIn(bom *cyclonedx.BOM, fn func(component cyclonedx.Component) error) error {
-	var components []cyclonedx.Component
+// isCycloneDXPackageType returns true for the types of component that are
+// software packages. The type is required by the spec, but an empty type is
+// treated as a library.
+func isCycloneDXPackageType(componentType cyclonedx.ComponentType) bool {
+	switch componentType {
+	case "", cyclonedx.ComponentTypeLibrary, cyclonedx.ComponentTypeFramework, cyclonedx.ComponentTypeApplication:
+		return true
+	}
+
+	return false
+}
+
+// foreachComponentIn calls fn for every component in the BOM. The component in
+// the metadata, which is the subject of the BOM, is called with subject set to
+// true.
+func foreachComponentIn(bom *cyclonedx.BOM, fn func(component cyclonedx.Component, subject bool) error) error {
 	if bom.Metadata != nil && bom.Metadata.Component != nil {
-		components = append(components, *bom.Metadata.Component)
+		if err := fn(*bom.Metadata.Component, true); err != nil {
+			return err
+		}
+		if bom.Metadata.Component.Components != nil {
+			if err := walkCycloneDXComponents(*bom.Metadata.Component.Components, fn); err != nil {
+				return err
+			}
+		}
 	}
-	if bom.Components != nil {
-		components = append(components, *bom.Components...)
+	if bom.Components == nil {
+		return nil
 	}
-	return walkCycloneDXComponents(components, fn)
+	return walkCycloneDXComponents(*bom.Components, fn)
 }
 
-func walkCycloneDXComponents(components []cyclonedx.Component, fn func(cyclonedx.Component) error) error {
+func walkCycloneDXComponents(components []cyclonedx.Component, fn func(cyclonedx.Component, bool) error) error {
 	for _, component := range components {
-		if err := fn(component); err != nil {
+		if err := fn(component, false); err != nil {
 			return err
 		}
 		if component.Components == nil {
@@ -149,8 +149,9 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.2.5",
 					},
 				},
 			},
@@ -173,20 +174,27 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.2.5",
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "maven",
-						Name: "org.hdrhistogram/HdrHistogram",
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "deb",
-						Name: "debian/adduser",
+						Type:    "deb",
+						Name:    "debian/adduser",
+						Version: "3.118",
+						Qualifiers: map[string]string{
+							"arch":   "all",
+							"distro": "debian-11",
+						},
 					},
 				},
 			},
@@ -220,35 +228,120 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.2.5",
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "sigs.k8s.io/release-utils",
+						Type:    "golang",
+						Name:    "sigs.k8s.io/release-utils",
+						Version: "v0.7.3",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/kubernetes-sigs/release-utils",
+						},
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "maven",
-						Name: "org.hdrhistogram/HdrHistogram",
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "maven",
-						Name: "com.github.package-url/packageurl-java",
+						Type:    "maven",
+						Name:    "com.github.package-url/packageurl-java",
+						Version: "1.4.1",
 					},
 				},
 			},
 		},
-		"components without a PackageURL should be ignored": {
+		"components without a PackageURL are identified by their group, name and CPE": {
 			bom: &cyclonedx.BOM{
 				Components: &[]cyclonedx.Component{
 					{
-						Name: "foo/bar",
+						Group:   "foo",
+						Name:    "bar",
+						Version: "1.0.0",
+						ExternalReferences: &[]cyclonedx.ExternalReference{
+							{
+								Type: cyclonedx.ERTypeVCS,
+								URL:  "https://github.com/foo/bar",
+							},
+						},
+					},
+					{
+						CPE: "cpe:/a:bar:baz:2.0.0",
+					},
+					{
+						Version: "3.0.0",
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "generic",
+						Name:    "foo/bar",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "generic",
+						Name:    "bar/baz",
+						Version: "2.0.0",
+						Qualifiers: map[string]string{
+							"cpe": "cpe:/a:bar:baz:2.0.0",
+						},
+					},
+				},
+			},
+		},
+		"the metadata component and components that aren't packages are ignored without a PackageURL": {
+			bom: &cyclonedx.BOM{
+				Metadata: &cyclonedx.Metadata{
+					Component: &cyclonedx.Component{
+						BOMRef:  "af63bd4c8601b7f1",
+						Type:    cyclonedx.ComponentTypeContainer,
+						Name:    "alpine:3.18",
+						Version: "sha256:c5c5fda71656f28e49ac9c5416b3643eaa6a108a8093151d6d1afc9463be8e33",
+					},
+				},
+				Components: &[]cyclonedx.Component{
+					{
+						Type:    cyclonedx.ComponentTypeOS,
+						Name:    "alpine",
+						Version: "3.18.0",
+						CPE:     "cpe:2.3:o:alpine:alpine_linux:3.18.0:*:*:*:*:*:*:*",
+					},
+					{
+						Type: cyclonedx.ComponentTypeFile,
+						Name: "/etc/os-release",
+					},
+					{
+						Type:    cyclonedx.ComponentTypeLibrary,
+						Name:    "busybox",
+						Version: "1.36.0",
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "generic",
+						Name:    "busybox",
+						Version: "1.36.0",
 					},
 				},
 			},
@@ -270,8 +363,16 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "maven",
-						Name: "org.hdrhistogram/HdrHistogram",
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.8",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
 					},
 				},
 			},
@@ -299,32 +400,42 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "maven",
-						Name: "org.hdrhistogram/HdrHistogram",
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "sigs.k8s.io/release-utils",
+						Type:    "golang",
+						Name:    "sigs.k8s.io/release-utils",
+						Version: "v0.7.3",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/kubernetes-sigs/release-utils",
+						},
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "npm",
-						Name: "zwitch",
+						Type:    "npm",
+						Name:    "zwitch",
+						Version: "2.0.2",
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "cargo",
-						Name: "getrandom",
+						Type:    "cargo",
+						Name:    "getrandom",
+						Version: "0.2.7",
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "pypi",
-						Name: "zope.interface",
+						Type:    "pypi",
+						Name:    "zope.interface",
+						Version: "5.4.0",
 					},
 				},
 			},
@@ -346,8 +457,9 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.1.1",
 					},
 					Repositories: []types.Repository{
 						{
@@ -374,8 +486,9 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.1.1",
 					},
 					Repositories: []types.Repository{
 						{
@@ -407,14 +520,16 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "bar/foo",
+						Type:    "golang",
+						Name:    "bar/foo",
+						Version: "v0.1.1",
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.1.1",
 					},
 					Repositories: []types.Repository{
 						{
@@ -445,8 +560,9 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.1.1",
 					},
 					Repositories: []types.Repository{
 						{
@@ -488,8 +604,9 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.1.1",
 					},
 					Repositories: []types.Repository{
 						{
@@ -530,8 +647,9 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.1.1",
 					},
 					Repositories: []types.Repository{
 						{
@@ -593,18 +711,31 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "foo/bar",
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.1.1",
 					},
 					Repositories: []types.Repository{
 						{
 							Name: "github.com/bar/foo",
 						},
 						{
-							Name: "github.com/foo/baz",
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.2.2",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/bar/foo",
 						},
 						{
-							Name: "github.com/foo/bar",
+							Name: "github.com/foo/baz",
 						},
 					},
 				},
@@ -621,8 +752,12 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "pypi",
-						Name: "foo.bar",
+						Type:    "pypi",
+						Name:    "foo.bar",
+						Version: "5.4.0",
+						Qualifiers: map[string]string{
+							"vcs_url": "git+git+ssh://git@github.com:foo/bar.git",
+						},
 					},
 					Repositories: []types.Repository{
 						{
@@ -632,6 +767,189 @@ func TestPackageRepositoriesFromCycloneDXBOM(t *testing.T) {
 				},
 			},
 		},
+		"subpaths are extracted from external references": {
+			bom: &cyclonedx.BOM{
+				Components: &[]cyclonedx.Component{
+					{
+						PackageURL: "pkg:npm/foo@1.0.0",
+						ExternalReferences: &[]cyclonedx.ExternalReference{
+							{
+								Type: cyclonedx.ERTypeVCS,
+								URL:  "https://github.com/foo/mono/tree/main/packages/foo",
+							},
+						},
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "foo",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name:    "github.com/foo/mono",
+							Subpath: "packages/foo",
+						},
+					},
+				},
+			},
+		},
+		"component scope is recorded": {
+			bom: &cyclonedx.BOM{
+				Components: &[]cyclonedx.Component{
+					{
+						PackageURL: "pkg:npm/foo@1.0.0",
+						Scope:      cyclonedx.ScopeOptional,
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "foo",
+						Version: "1.0.0",
+						Scope:   "optional",
+					},
+				},
+			},
+		},
+		"direct and transitive dependencies are discovered from the dependency graph": {
+			bom: &cyclonedx.BOM{
+				Metadata: &cyclonedx.Metadata{
+					Component: &cyclonedx.Component{
+						BOMRef:     "root",
+						PackageURL: "pkg:golang/foo/bar@v0.2.5",
+					},
+				},
+				Components: &[]cyclonedx.Component{
+					{
+						BOMRef:     "a",
+						PackageURL: "pkg:golang/foo/a@v1.0.0",
+					},
+					{
+						BOMRef:     "b",
+						PackageURL: "pkg:golang/foo/b@v1.0.0",
+					},
+					{
+						BOMRef:     "c",
+						PackageURL: "pkg:golang/foo/c@v1.0.0",
+					},
+					{
+						BOMRef:     "d",
+						PackageURL: "pkg:golang/foo/d@v1.0.0",
+					},
+				},
+				Dependencies: &[]cyclonedx.Dependency{
+					{
+						Ref:          "root",
+						Dependencies: &[]string{"a", "c"},
+					},
+					{
+						Ref:          "a",
+						Dependencies: &[]string{"b", "c"},
+					},
+					{
+						Ref:          "b",
+						Dependencies: &[]string{"d"},
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.2.5",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:           "golang",
+						Name:           "foo/a",
+						Version:        "v1.0.0",
+						DependencyType: types.DependencyTypeDirect,
+						Depth:          1,
+						Path:           []string{"foo/bar", "foo/a"},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:           "golang",
+						Name:           "foo/b",
+						Version:        "v1.0.0",
+						DependencyType: types.DependencyTypeTransitive,
+						Depth:          2,
+						Path:           []string{"foo/bar", "foo/a", "foo/b"},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:           "golang",
+						Name:           "foo/c",
+						Version:        "v1.0.0",
+						DependencyType: types.DependencyTypeDirect,
+						Depth:          1,
+						Path:           []string{"foo/bar", "foo/c"},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:           "golang",
+						Name:           "foo/d",
+						Version:        "v1.0.0",
+						DependencyType: types.DependencyTypeTransitive,
+						Depth:          3,
+						Path:           []string{"foo/bar", "foo/a", "foo/b", "foo/d"},
+					},
+				},
+			},
+		},
+		"components that nothing depends on are direct dependencies when there is no metadata.component": {
+			bom: &cyclonedx.BOM{
+				Components: &[]cyclonedx.Component{
+					{
+						BOMRef:     "a",
+						PackageURL: "pkg:golang/foo/a@v1.0.0",
+					},
+					{
+						BOMRef:     "b",
+						PackageURL: "pkg:golang/foo/b@v1.0.0",
+					},
+				},
+				Dependencies: &[]cyclonedx.Dependency{
+					{
+						Ref:          "a",
+						Dependencies: &[]string{"b"},
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:           "golang",
+						Name:           "foo/a",
+						Version:        "v1.0.0",
+						DependencyType: types.DependencyTypeDirect,
+						Depth:          1,
+						Path:           []string{"foo/a"},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:           "golang",
+						Name:           "foo/b",
+						Version:        "v1.0.0",
+						DependencyType: types.DependencyTypeTransitive,
+						Depth:          2,
+						Path:           []string{"foo/a", "foo/b"},
+					},
+				},
+			},
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
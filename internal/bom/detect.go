@@ -0,0 +1,116 @@
+package bom
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrUnknownFormat is returned when the format of a BOM can't be detected
+var ErrUnknownFormat = errors.New("unable to detect BOM format")
+
+// DetectFormat sniffs the content of a BOM to determine its format. It returns
+// the detected format and a reader that will replay the full content of the
+// BOM.
+func DetectFormat(r io.Reader) (Format, io.Reader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading BOM: %w", err)
+	}
+	// The parsers don't expect a byte order mark, so it's removed from the
+	// replayed content too
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	br := bytes.NewReader(data)
+
+	// Ignore any leading whitespace when deciding what kind of document
+	// we're looking at
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) == 0 {
+		return "", nil, fmt.Errorf("empty document: %w", ErrUnknownFormat)
+	}
+
+	switch trimmed[0] {
+	case '{':
+		format, err := detectJSONFormat(trimmed)
+		if err != nil {
+			return "", nil, err
+		}
+		return format, br, nil
+	case '<':
+		format, err := detectXMLFormat(trimmed)
+		if err != nil {
+			return "", nil, err
+		}
+		return format, br, nil
+	}
+
+	if isSPDXTagValue(trimmed) {
+		return FormatSPDXTagValue, br, nil
+	}
+
+	return "", nil, ErrUnknownFormat
+}
+
+func detectJSONFormat(data []byte) (Format, error) {
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("decoding json: %w", errors.Join(ErrUnknownFormat, err))
+	}
+
+	// DSSE envelopes and in-toto statements wrap another document
+	if _, ok := doc["payloadType"]; ok {
+		return FormatInToto, nil
+	}
+	if _, ok := doc["predicateType"]; ok {
+		return FormatInToto, nil
+	}
+	if _, ok := doc["bomFormat"]; ok {
+		return FormatCycloneDXJSON, nil
+	}
+	if _, ok := doc["spdxVersion"]; ok {
+		return FormatSPDXJSON, nil
+	}
+	if _, ok := doc["artifacts"]; ok {
+		return FormatSyftJSON, nil
+	}
+	if _, ok := doc["schema"]; ok {
+		return FormatSyftJSON, nil
+	}
+
+	return "", fmt.Errorf("json document: %w", ErrUnknownFormat)
+}
+
+func detectXMLFormat(data []byte) (Format, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("decoding xml: %w", errors.Join(ErrUnknownFormat, err))
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if el.Name.Local == "bom" {
+			return FormatCycloneDXXML, nil
+		}
+
+		return "", fmt.Errorf("unexpected xml root element %q: %w", el.Name.Local, ErrUnknownFormat)
+	}
+}
+
+func isSPDXTagValue(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if strings.HasPrefix(strings.TrimSpace(scanner.Text()), "SPDXVersion:") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package bom
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	testCases := map[string]struct {
+		path       string
+		content    string
+		wantFormat Format
+		wantErr    error
+	}{
+		"cyclonedx-json": {
+			path:       "testdata/cdx.json",
+			wantFormat: FormatCycloneDXJSON,
+		},
+		"cyclonedx-xml": {
+			path:       "testdata/cdx.xml",
+			wantFormat: FormatCycloneDXXML,
+		},
+		"syft-json": {
+			path:       "testdata/syft.json",
+			wantFormat: FormatSyftJSON,
+		},
+		"syft-json with only a schema": {
+			content:    `{"schema": {"version": "10.0.1"}}`,
+			wantFormat: FormatSyftJSON,
+		},
+		"spdx-json": {
+			path:       "testdata/spdx.json",
+			wantFormat: FormatSPDXJSON,
+		},
+		"spdx-tag-value": {
+			path:       "testdata/spdx.spdx",
+			wantFormat: FormatSPDXTagValue,
+		},
+		"in-toto statement": {
+			path:       "testdata/intoto.json",
+			wantFormat: FormatInToto,
+		},
+		"dsse envelope": {
+			path:       "testdata/dsse.json",
+			wantFormat: FormatInToto,
+		},
+		"cyclonedx-json with a byte order mark": {
+			path:       "testdata/cdx.bom.json",
+			wantFormat: FormatCycloneDXJSON,
+		},
+		"leading whitespace is ignored": {
+			content:    "\n\n   {\"bomFormat\": \"CycloneDX\"}",
+			wantFormat: FormatCycloneDXJSON,
+		},
+		"empty documents return ErrUnknownFormat": {
+			content: "  \n",
+			wantErr: ErrUnknownFormat,
+		},
+		"unrecognised json returns ErrUnknownFormat": {
+			content: `{"foo": "bar"}`,
+			wantErr: ErrUnknownFormat,
+		},
+		"invalid json returns ErrUnknownFormat": {
+			path:    "testdata/cdx.json.invalid",
+			wantErr: ErrUnknownFormat,
+		},
+		"unrecognised xml returns ErrUnknownFormat": {
+			content: `<?xml version="1.0"?><foo></foo>`,
+			wantErr: ErrUnknownFormat,
+		},
+		"unrecognised text returns ErrUnknownFormat": {
+			content: "foo: bar",
+			wantErr: ErrUnknownFormat,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var r io.Reader = strings.NewReader(tc.content)
+			if tc.path != "" {
+				f, err := os.Open(tc.path)
+				if err != nil {
+					t.Fatalf("unexpected error opening file: %s", err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			gotFormat, gotReader, err := DetectFormat(r)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotFormat != tc.wantFormat {
+				t.Errorf("unexpected format; wanted %s but got %s", tc.wantFormat, gotFormat)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			// The returned reader should be consumable by the parser for
+			// the detected format
+			if _, err := PackageRepositoriesFromBOM(gotReader, gotFormat); err != nil {
+				t.Errorf("unexpected error parsing BOM with detected format: %s", err)
+			}
+		})
+	}
+}
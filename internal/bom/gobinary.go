@@ -0,0 +1,72 @@
+package bom
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"io"
+	"path"
+	"runtime/debug"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+	"github.com/package-url/packageurl-go"
+)
+
+// ParseGoBinary reads the build information embedded in a compiled Go binary
+func ParseGoBinary(r io.ReaderAt) (*debug.BuildInfo, error) {
+	info, err := buildinfo.Read(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading go build info: %w", err)
+	}
+
+	return info, nil
+}
+
+// PackageRepositoriesFromGoBuildInfo discovers the modules compiled into a Go
+// binary
+func PackageRepositoriesFromGoBuildInfo(info *debug.BuildInfo) ([]*types.PackageRepositories, error) {
+	var pkgRepos []*types.PackageRepositories
+	for _, mod := range append([]*debug.Module{&info.Main}, info.Deps...) {
+		if mod == nil || mod.Path == "" {
+			continue
+		}
+
+		// Prefer the replacement module because that's the source that
+		// was actually compiled into the binary, unless it's a local
+		// directory
+		if mod.Replace != nil && mod.Replace.Path != "" && !isLocalGoModulePath(mod.Replace.Path) {
+			mod = mod.Replace
+		}
+
+		pkgRepo, err := packageRepositoriesFromPurl(goModulePurl(mod))
+		if err != nil {
+			return nil, err
+		}
+
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+	}
+
+	return pkgRepos, nil
+}
+
+func goModulePurl(mod *debug.Module) string {
+	namespace, name := path.Split(mod.Path)
+
+	version := mod.Version
+	if version == "(devel)" {
+		version = ""
+	}
+
+	return packageurl.NewPackageURL(
+		packageurl.TypeGolang,
+		strings.TrimSuffix(namespace, "/"),
+		name,
+		version,
+		nil,
+		"",
+	).ToString()
+}
+
+func isLocalGoModulePath(p string) bool {
+	return strings.HasPrefix(p, ".") || strings.HasPrefix(p, "/")
+}
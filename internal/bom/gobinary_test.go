@@ -0,0 +1,195 @@
+package bom
+
+import (
+	"os"
+	"runtime/debug"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestParseGoBinary(t *testing.T) {
+	// The test binary is itself a Go binary with embedded build info
+	path, err := os.Executable()
+	if err != nil {
+		t.Fatalf("unexpected error finding test binary: %s", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error opening file: %s", err)
+	}
+	defer f.Close()
+
+	info, err := ParseGoBinary(f)
+	if err != nil {
+		t.Fatalf("unexpected error parsing binary: %s", err)
+	}
+	pkgRepos, err := PackageRepositoriesFromGoBuildInfo(info)
+	if err != nil {
+		t.Fatalf("unexpected error getting packages from binary: %s", err)
+	}
+
+	wantPkgRepo := &types.PackageRepositories{
+		Package: types.Package{
+			Type: "golang",
+			Name: "github.com/google/go-cmp",
+		},
+		Repositories: []types.Repository{
+			{
+				Name: "github.com/google/go-cmp",
+			},
+		},
+	}
+	for _, pkgRepo := range pkgRepos {
+		if pkgRepo.Type != wantPkgRepo.Type || pkgRepo.Name != wantPkgRepo.Name {
+			continue
+		}
+		// The version depends on go.mod, so just check that there is
+		// one
+		if pkgRepo.Version == "" {
+			t.Errorf("expected %s to have a version", pkgRepo.Name)
+		}
+		if diff := cmp.Diff(wantPkgRepo.Repositories, pkgRepo.Repositories); diff != "" {
+			t.Errorf("unexpected repositories:\n%s", diff)
+		}
+		return
+	}
+	t.Errorf("expected to find %s in packages", wantPkgRepo.Name)
+}
+
+func TestParseGoBinaryInvalid(t *testing.T) {
+	f, err := os.Open("testdata/cdx.json")
+	if err != nil {
+		t.Fatalf("unexpected error opening file: %s", err)
+	}
+	defer f.Close()
+
+	if _, err := ParseGoBinary(f); err == nil {
+		t.Fatalf("expected error parsing a file that isn't a Go binary")
+	}
+}
+
+func TestPackageRepositoriesFromGoBuildInfo(t *testing.T) {
+	testCases := map[string]struct {
+		info         *debug.BuildInfo
+		wantPackages []*types.PackageRepositories
+	}{
+		"an error should not be produced for empty build info": {
+			info: &debug.BuildInfo{},
+		},
+		"main module and dependencies should be discovered": {
+			info: &debug.BuildInfo{
+				Main: debug.Module{
+					Path:    "github.com/foo/bar",
+					Version: "(devel)",
+				},
+				Deps: []*debug.Module{
+					{
+						Path:    "sigs.k8s.io/release-utils",
+						Version: "v0.7.3",
+					},
+					{
+						Path:    "github.com/foo/baz",
+						Version: "v1.2.3",
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "golang",
+						Name: "github.com/foo/bar",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "sigs.k8s.io/release-utils",
+						Version: "v0.7.3",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/kubernetes-sigs/release-utils",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "github.com/foo/baz",
+						Version: "v1.2.3",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/baz",
+						},
+					},
+				},
+			},
+		},
+		"replacements should be used unless they are local": {
+			info: &debug.BuildInfo{
+				Deps: []*debug.Module{
+					{
+						Path:    "github.com/foo/bar",
+						Version: "v1.0.0",
+						Replace: &debug.Module{
+							Path:    "github.com/bar/foo",
+							Version: "v1.0.1",
+						},
+					},
+					{
+						Path:    "github.com/foo/baz",
+						Version: "v1.0.0",
+						Replace: &debug.Module{
+							Path: "../baz",
+						},
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "github.com/bar/foo",
+						Version: "v1.0.1",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/bar/foo",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "github.com/foo/baz",
+						Version: "v1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/baz",
+						},
+					},
+				},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			gotPackages, err := PackageRepositoriesFromGoBuildInfo(tc.info)
+			if err != nil {
+				t.Fatalf("unexpected error getting packages from build info: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantPackages, gotPackages); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+		})
+	}
+}
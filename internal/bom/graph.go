@@ -0,0 +1,108 @@
+package bom
+
+import "github.com/jetstack/tally/internal/types"
+
+// dependencyGraph is a directed graph of references between the components in
+// a BOM
+type dependencyGraph struct {
+	edges    map[string][]string
+	incoming map[string]int
+}
+
+func newDependencyGraph() *dependencyGraph {
+	return &dependencyGraph{
+		edges:    map[string][]string{},
+		incoming: map[string]int{},
+	}
+}
+
+func (g *dependencyGraph) addEdge(from, to string) {
+	if _, ok := g.edges[to]; !ok {
+		g.edges[to] = nil
+	}
+	g.edges[from] = append(g.edges[from], to)
+	g.incoming[to]++
+}
+
+func (g *dependencyGraph) empty() bool {
+	return len(g.edges) == 0
+}
+
+// shortestPaths returns the shortest path from the root to every reachable
+// node. Each path starts with the root and ends with the node.
+//
+// If the root isn't in the graph then every node without incoming edges is
+// considered to be a direct dependency of the root.
+func (g *dependencyGraph) shortestPaths(root string) map[string][]string {
+	paths := map[string][]string{
+		root: {root},
+	}
+
+	// The edges from a missing root are only used for this search, so
+	// they aren't added to the graph
+	rootEdges, ok := g.edges[root]
+	if !ok {
+		for node := range g.edges {
+			if g.incoming[node] > 0 {
+				continue
+			}
+			rootEdges = append(rootEdges, node)
+		}
+	}
+
+	queue := []string{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		deps := g.edges[node]
+		if node == root {
+			deps = rootEdges
+		}
+		for _, dep := range deps {
+			if _, ok := paths[dep]; ok {
+				continue
+			}
+			path := make([]string, len(paths[node]), len(paths[node])+1)
+			copy(path, paths[node])
+			paths[dep] = append(path, dep)
+			queue = append(queue, dep)
+		}
+	}
+
+	return paths
+}
+
+// setDependencies records the position of each package in the dependency
+// graph, using the shortest path from the root
+func setDependencies(graph *dependencyGraph, root string, refNames map[string]string, refPkgs map[string]types.Package, pkgRepos []*types.PackageRepositories) {
+	if graph.empty() {
+		return
+	}
+
+	_, hasRoot := refNames[root]
+	for ref, refPath := range graph.shortestPaths(root) {
+		pkg, ok := refPkgs[ref]
+		if !ok {
+			continue
+		}
+
+		var path []string
+		for _, r := range refPath {
+			if r == root && !hasRoot {
+				continue
+			}
+			name, ok := refNames[r]
+			if !ok {
+				name = r
+			}
+			path = append(path, name)
+		}
+
+		for _, pkgRepo := range pkgRepos {
+			if !pkgRepo.Equals(pkg) {
+				continue
+			}
+			pkgRepo.SetDependency(len(refPath)-1, path)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package bom
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDependencyGraphShortestPaths(t *testing.T) {
+	graph := newDependencyGraph()
+	graph.addEdge("foo", "bar")
+	graph.addEdge("bar", "baz")
+	graph.addEdge("qux", "baz")
+
+	wantPaths := map[string][]string{
+		"root": {"root"},
+		"foo":  {"root", "foo"},
+		"qux":  {"root", "qux"},
+		"bar":  {"root", "foo", "bar"},
+		"baz":  {"root", "qux", "baz"},
+	}
+
+	// The root isn't in the graph, so the nodes without incoming edges are
+	// its dependencies. Searching again should give the same result and
+	// the graph shouldn't change.
+	for i := 0; i < 2; i++ {
+		if diff := cmp.Diff(wantPaths, graph.shortestPaths("root")); diff != "" {
+			t.Errorf("unexpected paths:\n%s", diff)
+		}
+	}
+	if _, ok := graph.edges["root"]; ok {
+		t.Errorf("unexpected edges added to the graph for the root")
+	}
+}
@@ -0,0 +1,72 @@
+package bom
+
+import (
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+// genericType is the purl type given to packages that don't have a purl
+const genericType = "generic"
+
+// packageRepositoriesFromIdentity synthesises a package for a component that
+// doesn't have a purl from its group, name and CPE. It returns nil when there
+// isn't enough information to identify the package.
+func packageRepositoriesFromIdentity(group, name, version, cpe string) *types.PackageRepositories {
+	vendor, product, cpeVersion := parseCPE(cpe)
+	if name == "" {
+		if product == "" {
+			return nil
+		}
+		group, name = vendor, product
+	}
+	if version == "" {
+		version = cpeVersion
+	}
+
+	pkgRepo := &types.PackageRepositories{
+		Package: types.Package{
+			Type:    genericType,
+			Name:    name,
+			Version: version,
+		},
+	}
+	if group != "" {
+		pkgRepo.Name = group + "/" + name
+	}
+	if cpe != "" {
+		pkgRepo.Qualifiers = map[string]string{
+			"cpe": cpe,
+		}
+	}
+
+	return pkgRepo
+}
+
+// parseCPE returns the vendor, product and version from a CPE in either the
+// 2.3 formatted string (cpe:2.3:a:vendor:product:version:...) or the 2.2 URI
+// (cpe:/a:vendor:product:version) format
+func parseCPE(cpe string) (string, string, string) {
+	var fields []string
+	switch {
+	case strings.HasPrefix(cpe, "cpe:2.3:"):
+		fields = strings.Split(strings.TrimPrefix(cpe, "cpe:2.3:"), ":")
+	case strings.HasPrefix(cpe, "cpe:/"):
+		fields = strings.Split(strings.TrimPrefix(cpe, "cpe:/"), ":")
+	default:
+		return "", "", ""
+	}
+
+	// Skip the part and treat any and not applicable values as empty
+	values := make([]string, 3)
+	for i := range values {
+		if i+1 >= len(fields) {
+			break
+		}
+		if v := fields[i+1]; v != "*" && v != "-" {
+			values[i] = v
+		}
+	}
+
+	return values[0], values[1], values[2]
+}
@@ -0,0 +1,81 @@
+package bom
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestPackageRepositoriesFromIdentity(t *testing.T) {
+	testCases := map[string]struct {
+		group       string
+		name        string
+		version     string
+		cpe         string
+		wantPkgRepo *types.PackageRepositories
+	}{
+		"group and name": {
+			group:   "foo",
+			name:    "bar",
+			version: "1.0.0",
+			wantPkgRepo: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "generic",
+					Name:    "foo/bar",
+					Version: "1.0.0",
+				},
+			},
+		},
+		"name is preferred to the CPE": {
+			name: "bar",
+			cpe:  "cpe:2.3:a:foo:baz:2.0.0:*:*:*:*:*:*:*",
+			wantPkgRepo: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "generic",
+					Name:    "bar",
+					Version: "2.0.0",
+					Qualifiers: map[string]string{
+						"cpe": "cpe:2.3:a:foo:baz:2.0.0:*:*:*:*:*:*:*",
+					},
+				},
+			},
+		},
+		"2.3 CPE with any version": {
+			cpe: "cpe:2.3:a:foo:bar:*:*:*:*:*:*:*:*",
+			wantPkgRepo: &types.PackageRepositories{
+				Package: types.Package{
+					Type: "generic",
+					Name: "foo/bar",
+					Qualifiers: map[string]string{
+						"cpe": "cpe:2.3:a:foo:bar:*:*:*:*:*:*:*:*",
+					},
+				},
+			},
+		},
+		"2.2 CPE without a version": {
+			cpe: "cpe:/a:foo:bar",
+			wantPkgRepo: &types.PackageRepositories{
+				Package: types.Package{
+					Type: "generic",
+					Name: "foo/bar",
+					Qualifiers: map[string]string{
+						"cpe": "cpe:/a:foo:bar",
+					},
+				},
+			},
+		},
+		"nothing to identify the package": {
+			version: "1.0.0",
+			cpe:     "not-a-cpe",
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			gotPkgRepo := packageRepositoriesFromIdentity(tc.group, tc.name, tc.version, tc.cpe)
+			if diff := cmp.Diff(tc.wantPkgRepo, gotPkgRepo); diff != "" {
+				t.Errorf("unexpected package:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package bom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/jetstack/tally/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// ignoreVersion is the version of the ignore file format that is supported
+const ignoreVersion = 1
+
+// ErrUnsupportedIgnoreVersion is returned when the ignore file has a version
+// that isn't supported
+var ErrUnsupportedIgnoreVersion = errors.New("unsupported ignore file version")
+
+// Ignore excludes packages from tally
+type Ignore struct {
+	Version int          `yaml:"version"`
+	Rules   []IgnoreRule `yaml:"ignore"`
+}
+
+// IgnoreRule matches the packages to exclude. Every field that is set must
+// match for a package to be excluded.
+type IgnoreRule struct {
+	// Type is the purl type of the package
+	Type string `yaml:"type"`
+
+	// Name is a glob that matches the package name, where * matches any
+	// sequence of characters, including /
+	Name string `yaml:"name"`
+
+	// Repository is a glob that matches any of the package's repositories
+	Repository string `yaml:"repository"`
+
+	// Scope is the CycloneDX component scope (required, optional or
+	// excluded)
+	Scope string `yaml:"scope"`
+
+	name       *regexp.Regexp
+	repository *regexp.Regexp
+}
+
+// ParseIgnore parses an ignore file in YAML or JSON format
+func ParseIgnore(r io.Reader) (*Ignore, error) {
+	i := &Ignore{}
+	if err := yaml.NewDecoder(r).Decode(i); err != nil {
+		return nil, fmt.Errorf("decoding ignore file: %w", err)
+	}
+	if i.Version != ignoreVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedIgnoreVersion, i.Version)
+	}
+	for n := range i.Rules {
+		if err := i.Rules[n].compile(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", n, err)
+		}
+	}
+
+	return i, nil
+}
+
+// Add adds rules to the ignore list
+func (i *Ignore) Add(rules ...IgnoreRule) error {
+	for n := range rules {
+		if err := rules[n].compile(); err != nil {
+			return err
+		}
+		i.Rules = append(i.Rules, rules[n])
+	}
+
+	return nil
+}
+
+// Filter splits the packages into those that should be included and those
+// that match an ignore rule
+func (i *Ignore) Filter(pkgRepos ...*types.PackageRepositories) (included, excluded []*types.PackageRepositories) {
+	for _, pkgRepo := range pkgRepos {
+		if i.match(pkgRepo) {
+			excluded = append(excluded, pkgRepo)
+			continue
+		}
+		included = append(included, pkgRepo)
+	}
+
+	return included, excluded
+}
+
+func (i *Ignore) match(pkgRepo *types.PackageRepositories) bool {
+	for _, rule := range i.Rules {
+		if rule.match(pkgRepo) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *IgnoreRule) compile() error {
+	if r.Type == "" && r.Name == "" && r.Repository == "" && r.Scope == "" {
+		return fmt.Errorf("at least one of type, name, repository or scope must be set")
+	}
+	if r.Name != "" {
+		r.name = globRegex(r.Name)
+	}
+	if r.Repository != "" {
+		r.repository = globRegex(r.Repository)
+	}
+
+	return nil
+}
+
+func (r *IgnoreRule) match(pkgRepo *types.PackageRepositories) bool {
+	if r.Type != "" && r.Type != pkgRepo.Type {
+		return false
+	}
+	if r.Scope != "" && r.Scope != pkgRepo.Scope {
+		return false
+	}
+	if r.name != nil && !r.name.MatchString(pkgRepo.Name) {
+		return false
+	}
+	if r.repository == nil {
+		return true
+	}
+	for _, repo := range pkgRepo.Repositories {
+		if r.repository.MatchString(repo.Name) {
+			return true
+		}
+	}
+
+	return false
+}
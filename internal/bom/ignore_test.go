@@ -0,0 +1,134 @@
+package bom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestIgnore(t *testing.T) {
+	pkgRepos := []*types.PackageRepositories{
+		{
+			Package: types.Package{
+				Type: "golang",
+				Name: "example.com/internal/foo",
+			},
+		},
+		{
+			Package: types.Package{
+				Type: "npm",
+				Name: "foo",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+				{
+					Name: "github.com/example/foo",
+				},
+			},
+		},
+		{
+			Package: types.Package{
+				Type:  "npm",
+				Name:  "bar",
+				Scope: "optional",
+			},
+		},
+		{
+			Package: types.Package{
+				Type: "pypi",
+				Name: "foo",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/foo",
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		data         string
+		rules        []IgnoreRule
+		wantExcluded []string
+		wantErr      error
+	}{
+		"packages are excluded by the rules in the file": {
+			data: `
+version: 1
+ignore:
+  - name: example.com/internal/*
+  - repository: github.com/example/*
+  - type: npm
+    scope: optional
+`,
+			wantExcluded: []string{
+				"example.com/internal/foo",
+				"foo",
+				"bar",
+			},
+		},
+		"every field in a rule must match": {
+			data: `{"version": 1, "ignore": [{"type": "pypi", "name": "bar"}, {"type": "npm", "scope": "required"}]}`,
+		},
+		"rules can be added to the file": {
+			data: `{"version": 1, "ignore": [{"type": "golang"}]}`,
+			rules: []IgnoreRule{
+				{
+					Type: "pypi",
+				},
+				{
+					Repository: "github.com/*/bar",
+				},
+			},
+			wantExcluded: []string{
+				"example.com/internal/foo",
+				"foo",
+				"foo",
+			},
+		},
+		"unsupported versions return ErrUnsupportedIgnoreVersion": {
+			data:    `{"version": 2}`,
+			wantErr: ErrUnsupportedIgnoreVersion,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ignore, err := ParseIgnore(strings.NewReader(tc.data))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if err := ignore.Add(tc.rules...); err != nil {
+				t.Fatalf("unexpected error adding rules: %s", err)
+			}
+
+			included, excluded := ignore.Filter(pkgRepos...)
+			if len(included)+len(excluded) != len(pkgRepos) {
+				t.Fatalf("expected %d packages but got %d", len(pkgRepos), len(included)+len(excluded))
+			}
+			var gotExcluded []string
+			for _, pkgRepo := range excluded {
+				gotExcluded = append(gotExcluded, pkgRepo.Name)
+			}
+			if diff := cmp.Diff(tc.wantExcluded, gotExcluded); diff != "" {
+				t.Errorf("unexpected excluded packages:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIgnoreRulesMustNotBeEmpty(t *testing.T) {
+	if _, err := ParseIgnore(strings.NewReader(`{"version": 1, "ignore": [{}]}`)); err == nil {
+		t.Errorf("expected error parsing an empty rule")
+	}
+	if err := (&Ignore{}).Add(IgnoreRule{}); err == nil {
+		t.Errorf("expected error adding an empty rule")
+	}
+}
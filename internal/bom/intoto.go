@@ -0,0 +1,111 @@
+package bom
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	dssePayloadTypeInToto = "application/vnd.in-toto+json"
+
+	predicateTypeCycloneDX = "https://cyclonedx.org/bom"
+	predicateTypeSPDX      = "https://spdx.dev/Document"
+)
+
+// ErrUnsupportedPayloadType is returned when a DSSE envelope doesn't contain
+// an in-toto statement
+var ErrUnsupportedPayloadType = errors.New("unsupported DSSE payload type")
+
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+}
+
+type inTotoStatement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// UnwrapAttestation extracts the SBOM from the predicate of an in-toto
+// statement, which may itself be the payload of a DSSE envelope. It returns
+// the format of the SBOM and a reader for its content.
+func UnwrapAttestation(r io.Reader) (Format, io.Reader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading attestation: %w", err)
+	}
+
+	doc := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", nil, fmt.Errorf("decoding attestation: %w", err)
+	}
+
+	// Decode the statement from the payload of the envelope
+	if _, ok := doc["payloadType"]; ok {
+		data, err = decodeDSSEPayload(data)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	statement := &inTotoStatement{}
+	if err := json.Unmarshal(data, statement); err != nil {
+		return "", nil, fmt.Errorf("decoding in-toto statement: %w", err)
+	}
+	if len(statement.Predicate) == 0 {
+		return "", nil, fmt.Errorf("in-toto statement has no predicate")
+	}
+
+	// Some tools store the SBOM in the predicate as a string, rather than
+	// as a JSON object
+	predicate := []byte(statement.Predicate)
+	var s string
+	if err := json.Unmarshal(predicate, &s); err == nil {
+		predicate = []byte(s)
+	}
+
+	switch {
+	case strings.HasPrefix(statement.PredicateType, predicateTypeCycloneDX):
+		return FormatCycloneDXJSON, bytes.NewReader(predicate), nil
+	case strings.HasPrefix(statement.PredicateType, predicateTypeSPDX):
+		if format, _, err := DetectFormat(bytes.NewReader(predicate)); err == nil && format == FormatSPDXTagValue {
+			return FormatSPDXTagValue, bytes.NewReader(predicate), nil
+		}
+		return FormatSPDXJSON, bytes.NewReader(predicate), nil
+	}
+
+	// Fallback to inspecting the predicate for other predicate types
+	format, pr, err := DetectFormat(bytes.NewReader(predicate))
+	if err != nil {
+		return "", nil, fmt.Errorf("predicate type %q: %w", statement.PredicateType, err)
+	}
+
+	return format, pr, nil
+}
+
+func decodeDSSEPayload(data []byte) ([]byte, error) {
+	envelope := &dsseEnvelope{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return nil, fmt.Errorf("decoding DSSE envelope: %w", err)
+	}
+	if envelope.PayloadType != dssePayloadTypeInToto {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPayloadType, envelope.PayloadType)
+	}
+
+	// The DSSE spec allows both standard and URL-safe base64 encoding
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		payload, err = base64.URLEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("decoding DSSE payload: %w", err)
+		}
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,115 @@
+package bom
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestUnwrapAttestation(t *testing.T) {
+	testCases := map[string]struct {
+		path         string
+		content      string
+		wantFormat   Format
+		wantPackages []*types.PackageRepositories
+		wantErr      error
+	}{
+		"in-toto statement with a cyclonedx predicate": {
+			path:       "testdata/intoto.json",
+			wantFormat: FormatCycloneDXJSON,
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "github.com/foo/bar",
+						Version: "v0.2.5",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+			},
+		},
+		"dsse envelope with an spdx predicate": {
+			path:       "testdata/dsse.json",
+			wantFormat: FormatSPDXJSON,
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.2.5",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/hdrhistogram/hdrhistogram",
+						},
+					},
+				},
+			},
+		},
+		"predicates stored as a string are decoded": {
+			content:    `{"_type": "https://in-toto.io/Statement/v0.1", "predicateType": "https://cyclonedx.org/bom/v1.4", "predicate": "{\"bomFormat\": \"CycloneDX\", \"specVersion\": \"1.4\", \"version\": 1}"}`,
+			wantFormat: FormatCycloneDXJSON,
+		},
+		"unknown predicate types are detected from the predicate": {
+			content:    `{"_type": "https://in-toto.io/Statement/v0.1", "predicateType": "https://example.com/sbom", "predicate": {"schema": {"version": "10.0.1"}}}`,
+			wantFormat: FormatSyftJSON,
+		},
+		"unrecognised predicates return ErrUnknownFormat": {
+			content: `{"_type": "https://in-toto.io/Statement/v0.1", "predicateType": "https://slsa.dev/provenance/v0.2", "predicate": {"builder": {"id": "foo"}}}`,
+			wantErr: ErrUnknownFormat,
+		},
+		"unsupported payload types return ErrUnsupportedPayloadType": {
+			content: `{"payloadType": "application/vnd.foo+json", "payload": "` + base64.StdEncoding.EncodeToString([]byte(`{}`)) + `"}`,
+			wantErr: ErrUnsupportedPayloadType,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var r io.Reader = strings.NewReader(tc.content)
+			if tc.path != "" {
+				f, err := os.Open(tc.path)
+				if err != nil {
+					t.Fatalf("unexpected error opening file: %s", err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			gotFormat, gotReader, err := UnwrapAttestation(r)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotFormat != tc.wantFormat {
+				t.Errorf("unexpected format; wanted %s but got %s", tc.wantFormat, gotFormat)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			gotPackages, err := PackageRepositoriesFromBOM(gotReader, gotFormat)
+			if err != nil {
+				t.Fatalf("unexpected error parsing predicate: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantPackages, gotPackages); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package bom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+	"github.com/package-url/packageurl-go"
+)
+
+// ErrUnsupportedLockfile is returned when a file isn't a recognised lockfile
+var ErrUnsupportedLockfile = errors.New("unsupported lockfile")
+
+type lockfileParser func(io.Reader) ([]*types.PackageRepositories, error)
+
+// lockfileParsers maps the names of supported lockfiles to their parser
+var lockfileParsers = map[string]lockfileParser{
+	"go.mod":                  packageRepositoriesFromGoMod,
+	"go.sum":                  packageRepositoriesFromGoSum,
+	"package-lock.json":       packageRepositoriesFromPackageLock,
+	"yarn.lock":               packageRepositoriesFromYarnLock,
+	"Cargo.lock":              packageRepositoriesFromCargoLock,
+	"poetry.lock":             packageRepositoriesFromPoetryLock,
+	"requirements.txt":        packageRepositoriesFromRequirements,
+	"Chart.yaml":              packageRepositoriesFromChart,
+	"Chart.lock":              packageRepositoriesFromChartLock,
+	".pre-commit-config.yaml": packageRepositoriesFromPreCommitConfig,
+	".gitmodules":             packageRepositoriesFromGitmodules,
+}
+
+// lockedParsers replace the parser of a manifest when its lockfile is in the
+// same directory, so that packages aren't discovered from both
+var lockedParsers = map[string]struct {
+	lockfile string
+	parse    lockfileParser
+}{
+	"Chart.yaml": {"Chart.lock", packageRepositoriesFromChartWithLock},
+}
+
+// Lockfiles are the names of all the supported lockfiles
+var Lockfiles = []string{
+	"go.mod",
+	"go.sum",
+	"package-lock.json",
+	"yarn.lock",
+	"Cargo.lock",
+	"poetry.lock",
+	"requirements.txt",
+	"Chart.yaml",
+	"Chart.lock",
+	".pre-commit-config.yaml",
+	".gitmodules",
+}
+
+// PackageRepositoriesFromLockfile discovers packages in a lockfile or, if the
+// path is a directory, in all of the supported lockfiles in that directory.
+// The type of lockfile is determined by its name.
+func PackageRepositoriesFromLockfile(path string) ([]*types.PackageRepositories, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return packageRepositoriesFromLockfile(path)
+	}
+
+	var (
+		pkgRepos []*types.PackageRepositories
+		found    bool
+	)
+	for _, name := range Lockfiles {
+		lockfilePath := filepath.Join(path, name)
+		if _, err := os.Stat(lockfilePath); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		found = true
+
+		parse := lockfileParsers[name]
+		if locked, ok := lockedParsers[name]; ok {
+			if _, err := os.Stat(filepath.Join(path, locked.lockfile)); err == nil {
+				parse = locked.parse
+			}
+		}
+		lockfilePkgRepos, err := parseLockfile(lockfilePath, parse)
+		if err != nil {
+			return nil, err
+		}
+		pkgRepos = MergePackageRepositories(pkgRepos, lockfilePkgRepos...)
+	}
+	if !found {
+		return nil, fmt.Errorf("no lockfiles found in %s: %w", path, ErrUnsupportedLockfile)
+	}
+
+	return pkgRepos, nil
+}
+
+func packageRepositoriesFromLockfile(path string) ([]*types.PackageRepositories, error) {
+	parse, ok := lockfileParsers[filepath.Base(path)]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", path, ErrUnsupportedLockfile)
+	}
+
+	return parseLockfile(path, parse)
+}
+
+func parseLockfile(path string, parse lockfileParser) ([]*types.PackageRepositories, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pkgRepos, err := parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return pkgRepos, nil
+}
+
+// packageRepositoriesFromLockfileEntry builds a purl for a package found in a
+// lockfile and discovers its repositories. The VCS url is optional.
+func packageRepositoriesFromLockfileEntry(purlType, namespace, name, version, vcsURL string) (*types.PackageRepositories, error) {
+	var qualifiers packageurl.Qualifiers
+	if vcsURL != "" {
+		qualifiers = packageurl.QualifiersFromMap(map[string]string{
+			"vcs_url": trimVCSURL(vcsURL),
+		})
+	}
+
+	return packageRepositoriesFromPurl(packageurl.NewPackageURL(purlType, namespace, name, version, qualifiers, "").ToString())
+}
+
+// trimVCSURL removes the query string and fragment (i.e ?branch=main or
+// #egg=foo) from a VCS url found in a lockfile. They aren't escaped properly by
+// packageurl-go and don't identify the repository anyway.
+func trimVCSURL(vcsURL string) string {
+	if idx := strings.IndexAny(vcsURL, "?#"); idx != -1 {
+		return vcsURL[:idx]
+	}
+
+	return vcsURL
+}
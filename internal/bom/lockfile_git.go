@@ -0,0 +1,49 @@
+package bom
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+// GitSubmoduleType is the package type of git submodules
+const GitSubmoduleType = "git-submodule"
+
+// packageRepositoriesFromGitmodules discovers the repositories of the
+// submodules in a .gitmodules file, i.e:
+//
+//	[submodule "foo"]
+//		path = foo
+//		url = https://github.com/foo/bar.git
+func packageRepositoriesFromGitmodules(r io.Reader) ([]*types.PackageRepositories, error) {
+	var pkgRepos []*types.PackageRepositories
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		// Only the url of each submodule is needed
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(key) != "url" {
+			continue
+		}
+
+		// Relative urls point to repositories alongside the superproject,
+		// which depends on the remote it was cloned from
+		u := strings.TrimSpace(value)
+		if strings.HasPrefix(u, "./") || strings.HasPrefix(u, "../") {
+			continue
+		}
+
+		pkgRepos = appendPackageRepositories(pkgRepos, packageRepositoriesFromURL(GitSubmoduleType, u, ""))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return pkgRepos, nil
+}
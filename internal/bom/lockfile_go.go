@@ -0,0 +1,71 @@
+package bom
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+	"golang.org/x/mod/modfile"
+)
+
+func packageRepositoriesFromGoMod(r io.Reader) ([]*types.PackageRepositories, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.ParseLax("go.mod", data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgRepos []*types.PackageRepositories
+	for _, req := range f.Require {
+		pkgRepo, err := packageRepositoriesFromGoModule(req.Mod.Path, req.Mod.Version)
+		if err != nil {
+			return nil, err
+		}
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+	}
+
+	return pkgRepos, nil
+}
+
+func packageRepositoriesFromGoSum(r io.Reader) ([]*types.PackageRepositories, error) {
+	var pkgRepos []*types.PackageRepositories
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected number of fields in line; wanted 3 but got %d", len(fields))
+		}
+
+		// Entries for go.mod files are for modules in the build graph
+		// that don't necessarily contribute any code
+		if strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+
+		pkgRepo, err := packageRepositoriesFromGoModule(fields[0], fields[1])
+		if err != nil {
+			return nil, err
+		}
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return pkgRepos, nil
+}
+
+func packageRepositoriesFromGoModule(modulePath, version string) (*types.PackageRepositories, error) {
+	namespace, name := path.Split(modulePath)
+
+	return packageRepositoriesFromLockfileEntry("golang", strings.TrimSuffix(namespace, "/"), name, version, "")
+}
@@ -0,0 +1,114 @@
+package bom
+
+import (
+	"errors"
+	"io"
+
+	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
+	"gopkg.in/yaml.v3"
+)
+
+// HelmType is the package type of Helm charts
+const HelmType = "helm"
+
+type helmChart struct {
+	Name         string           `yaml:"name"`
+	Version      string           `yaml:"version"`
+	Sources      []string         `yaml:"sources"`
+	Dependencies []helmDependency `yaml:"dependencies"`
+}
+
+type helmDependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+}
+
+// packageRepositoriesFromChart discovers the repositories of a chart from the
+// sources in its Chart.yaml, along with the chart's dependencies. The versions
+// of dependencies in a Chart.yaml are constraints, so they're left empty.
+func packageRepositoriesFromChart(r io.Reader) ([]*types.PackageRepositories, error) {
+	pkgRepos, chart, err := packageRepositoriesFromChartSources(r)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]helmDependency, len(chart.Dependencies))
+	for i, dep := range chart.Dependencies {
+		dep.Version = ""
+		deps[i] = dep
+	}
+
+	return appendHelmDependencies(pkgRepos, deps...), nil
+}
+
+// packageRepositoriesFromChartWithLock discovers the repositories of a chart
+// from the sources in its Chart.yaml, when the dependencies are discovered
+// from its Chart.lock instead
+func packageRepositoriesFromChartWithLock(r io.Reader) ([]*types.PackageRepositories, error) {
+	pkgRepos, _, err := packageRepositoriesFromChartSources(r)
+
+	return pkgRepos, err
+}
+
+func packageRepositoriesFromChartSources(r io.Reader) ([]*types.PackageRepositories, *helmChart, error) {
+	chart := &helmChart{}
+	if err := yaml.NewDecoder(r).Decode(chart); err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+
+	var pkgRepos []*types.PackageRepositories
+	if chart.Name != "" {
+		pkgRepo := &types.PackageRepositories{
+			Package: types.Package{
+				Type:    HelmType,
+				Name:    chart.Name,
+				Version: chart.Version,
+			},
+		}
+		for _, source := range chart.Sources {
+			if repo := vcs_url.ToRepository(source); repo != nil {
+				pkgRepo.AddRepositories(*repo)
+			}
+		}
+		pkgRepos = append(pkgRepos, pkgRepo)
+	}
+
+	return pkgRepos, chart, nil
+}
+
+// packageRepositoriesFromChartLock discovers the dependencies of a chart from
+// its Chart.lock
+func packageRepositoriesFromChartLock(r io.Reader) ([]*types.PackageRepositories, error) {
+	lock := &helmChart{}
+	if err := yaml.NewDecoder(r).Decode(lock); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return appendHelmDependencies(nil, lock.Dependencies...), nil
+}
+
+// appendHelmDependencies adds chart dependencies to the packages. The
+// repository of a dependency is usually a chart repository, rather than a
+// source repository, so most dependencies won't have a repository.
+func appendHelmDependencies(pkgRepos []*types.PackageRepositories, deps ...helmDependency) []*types.PackageRepositories {
+	for _, dep := range deps {
+		if dep.Name == "" {
+			continue
+		}
+		pkgRepo := &types.PackageRepositories{
+			Package: types.Package{
+				Type:    HelmType,
+				Name:    dep.Name,
+				Version: dep.Version,
+			},
+		}
+		if repo := vcs_url.ToRepository(dep.Repository); repo != nil {
+			pkgRepo.AddRepositories(*repo)
+		}
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+	}
+
+	return pkgRepos
+}
@@ -0,0 +1,171 @@
+package bom
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+type packageLock struct {
+	// Packages is used by lockfileVersion 2 and 3
+	Packages map[string]packageLockPackage `json:"packages"`
+
+	// Dependencies is used by lockfileVersion 1
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+type packageLockPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Link    bool   `json:"link"`
+}
+
+type packageLockDependency struct {
+	Version      string                           `json:"version"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+func packageRepositoriesFromPackageLock(r io.Reader) ([]*types.PackageRepositories, error) {
+	lock := &packageLock{}
+	if err := json.NewDecoder(r).Decode(lock); err != nil {
+		return nil, err
+	}
+
+	var pkgRepos []*types.PackageRepositories
+	if len(lock.Packages) > 0 {
+		for _, key := range sortedKeys(lock.Packages) {
+			pkg := lock.Packages[key]
+
+			// The root package has an empty key and links point at
+			// local directories
+			if key == "" || pkg.Link {
+				continue
+			}
+			name := pkg.Name
+			if name == "" {
+				idx := strings.LastIndex(key, "node_modules/")
+				if idx == -1 {
+					continue
+				}
+				name = key[idx+len("node_modules/"):]
+			}
+
+			pkgRepo, err := packageRepositoriesFromNpmPackage(name, pkg.Version)
+			if err != nil {
+				return nil, err
+			}
+			pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+		}
+
+		return pkgRepos, nil
+	}
+
+	return appendPackageLockDependencies(pkgRepos, lock.Dependencies)
+}
+
+func appendPackageLockDependencies(pkgRepos []*types.PackageRepositories, deps map[string]packageLockDependency) ([]*types.PackageRepositories, error) {
+	for _, name := range sortedKeys(deps) {
+		dep := deps[name]
+		pkgRepo, err := packageRepositoriesFromNpmPackage(name, dep.Version)
+		if err != nil {
+			return nil, err
+		}
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+
+		pkgRepos, err = appendPackageLockDependencies(pkgRepos, dep.Dependencies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pkgRepos, nil
+}
+
+func packageRepositoriesFromYarnLock(r io.Reader) ([]*types.PackageRepositories, error) {
+	var (
+		pkgRepos []*types.PackageRepositories
+		name     string
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Unindented lines are the start of an entry and list the
+		// specifiers that resolve to it, i.e:
+		//   "@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+		if !strings.HasPrefix(line, " ") {
+			spec := strings.TrimSuffix(line, ":")
+			spec, _, _ = strings.Cut(spec, ",")
+			spec = strings.Trim(strings.TrimSpace(spec), `"`)
+			name = yarnPackageName(spec)
+			continue
+		}
+		if name == "" {
+			continue
+		}
+
+		// Yarn v1 uses `version "1.2.3"` and later versions use
+		// `version: 1.2.3`
+		field := strings.TrimSpace(line)
+		if !strings.HasPrefix(field, "version ") && !strings.HasPrefix(field, "version:") {
+			continue
+		}
+		version := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(field, "version"), ":"))
+		version = strings.Trim(version, `"`)
+
+		pkgRepo, err := packageRepositoriesFromNpmPackage(name, version)
+		if err != nil {
+			return nil, err
+		}
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+		name = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return pkgRepos, nil
+}
+
+// yarnPackageName extracts the package name from a specifier like
+// @foo/bar@^1.0.0 or @foo/bar@npm:^1.0.0
+func yarnPackageName(spec string) string {
+	if spec == "__metadata" {
+		return ""
+	}
+	idx := strings.Index(strings.TrimPrefix(spec, "@"), "@")
+	if idx == -1 {
+		return spec
+	}
+	if strings.HasPrefix(spec, "@") {
+		idx++
+	}
+
+	return spec[:idx]
+}
+
+func packageRepositoriesFromNpmPackage(name, version string) (*types.PackageRepositories, error) {
+	var namespace string
+	if strings.HasPrefix(name, "@") {
+		namespace, name, _ = strings.Cut(name, "/")
+	}
+
+	return packageRepositoriesFromLockfileEntry("npm", namespace, name, version, "")
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
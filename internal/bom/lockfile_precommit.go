@@ -0,0 +1,62 @@
+package bom
+
+import (
+	"errors"
+	"io"
+
+	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
+	"gopkg.in/yaml.v3"
+)
+
+// PreCommitType is the package type of pre-commit hook repositories
+const PreCommitType = "pre-commit"
+
+type preCommitConfig struct {
+	Repos []preCommitRepo `yaml:"repos"`
+}
+
+type preCommitRepo struct {
+	Repo string `yaml:"repo"`
+	Rev  string `yaml:"rev"`
+}
+
+func packageRepositoriesFromPreCommitConfig(r io.Reader) ([]*types.PackageRepositories, error) {
+	config := &preCommitConfig{}
+	if err := yaml.NewDecoder(r).Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var pkgRepos []*types.PackageRepositories
+	for _, repo := range config.Repos {
+		// The local and meta repositories are hooks defined in the
+		// config itself or by pre-commit
+		switch repo.Repo {
+		case "", "local", "meta":
+			continue
+		}
+
+		pkgRepos = appendPackageRepositories(pkgRepos, packageRepositoriesFromURL(PreCommitType, repo.Repo, repo.Rev))
+	}
+
+	return pkgRepos, nil
+}
+
+// packageRepositoriesFromURL returns a package that is identified by the url of
+// its repository. The package is named after the repository, when it's on one
+// of the known hosts, or the url otherwise.
+func packageRepositoriesFromURL(pkgType, u, version string) *types.PackageRepositories {
+	pkgRepo := &types.PackageRepositories{
+		Package: types.Package{
+			Type:    pkgType,
+			Name:    u,
+			Version: version,
+		},
+	}
+	if repo := vcs_url.ToRepository(u); repo != nil {
+		pkgRepo.Name = repo.Name
+		pkgRepo.AddRepositories(*repo)
+	}
+
+	return pkgRepo
+}
@@ -0,0 +1,138 @@
+package bom
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jetstack/tally/internal/types"
+)
+
+type poetryLock struct {
+	Packages []poetryLockPackage `toml:"package"`
+}
+
+type poetryLockPackage struct {
+	Name    string                  `toml:"name"`
+	Version string                  `toml:"version"`
+	Source  poetryLockPackageSource `toml:"source"`
+}
+
+type poetryLockPackageSource struct {
+	Type string `toml:"type"`
+	URL  string `toml:"url"`
+}
+
+func packageRepositoriesFromPoetryLock(r io.Reader) ([]*types.PackageRepositories, error) {
+	lock := &poetryLock{}
+	if _, err := toml.NewDecoder(r).Decode(lock); err != nil {
+		return nil, err
+	}
+
+	var pkgRepos []*types.PackageRepositories
+	for _, pkg := range lock.Packages {
+		var vcsURL string
+		if pkg.Source.Type == "git" {
+			vcsURL = pkg.Source.URL
+		}
+
+		pkgRepo, err := packageRepositoriesFromLockfileEntry("pypi", "", pkg.Name, pkg.Version, vcsURL)
+		if err != nil {
+			return nil, err
+		}
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+	}
+
+	return pkgRepos, nil
+}
+
+var (
+	// requirementRegex matches the name and, optionally, the pinned
+	// version of a requirement like foo[bar]==1.2.3
+	requirementRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(?:===?\s*([^\s;,]+))?`)
+
+	// eggRegex matches the package name in a VCS requirement like
+	// git+https://github.com/foo/bar.git#egg=bar
+	eggRegex = regexp.MustCompile(`[#&]egg=([A-Za-z0-9._-]+)`)
+)
+
+func packageRepositoriesFromRequirements(r io.Reader) ([]*types.PackageRepositories, error) {
+	var pkgRepos []*types.PackageRepositories
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, " #"); idx != -1 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var name, version, vcsURL string
+		switch {
+		// Editable VCS requirements, i.e:
+		//   -e git+https://github.com/foo/bar.git#egg=bar
+		case strings.HasPrefix(line, "-e ") || strings.HasPrefix(line, "--editable "):
+			_, vcsURL, _ = strings.Cut(line, " ")
+			vcsURL = strings.TrimSpace(vcsURL)
+			matches := eggRegex.FindStringSubmatch(vcsURL)
+			if len(matches) < 2 {
+				continue
+			}
+			name = matches[1]
+			vcsURL = trimPipVCSRef(vcsURL)
+		// Other options, like -r and --index-url
+		case strings.HasPrefix(line, "-"):
+			continue
+		default:
+			// Direct references, i.e: bar @ git+https://github.com/foo/bar.git
+			if spec, url, ok := strings.Cut(line, " @ "); ok {
+				line = spec
+				vcsURL = trimPipVCSRef(strings.TrimSpace(url))
+			}
+			matches := requirementRegex.FindStringSubmatch(line)
+			if len(matches) < 3 {
+				continue
+			}
+			name = matches[1]
+			version = matches[2]
+		}
+
+		pkgRepo, err := packageRepositoriesFromLockfileEntry("pypi", "", name, version, vcsURL)
+		if err != nil {
+			return nil, err
+		}
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return pkgRepos, nil
+}
+
+// trimPipVCSRef removes the revision from a pip VCS url, i.e:
+// git+https://github.com/foo/bar.git@v1.0.0 -> git+https://github.com/foo/bar.git
+func trimPipVCSRef(u string) string {
+	_, rest, ok := strings.Cut(u, "://")
+	if !ok {
+		return u
+	}
+	offset := len(u) - len(rest)
+	if idx := strings.LastIndex(rest, "/"); idx != -1 {
+		offset += idx
+	}
+	at := strings.Index(u[offset:], "@")
+	if at == -1 {
+		return u
+	}
+	end := len(u)
+	if hash := strings.Index(u[offset+at:], "#"); hash != -1 {
+		end = offset + at + hash
+	}
+
+	return u[:offset+at] + u[end:]
+}
@@ -0,0 +1,44 @@
+package bom
+
+import (
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jetstack/tally/internal/types"
+)
+
+type cargoLock struct {
+	Packages []cargoLockPackage `toml:"package"`
+}
+
+type cargoLockPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	Source  string `toml:"source"`
+}
+
+func packageRepositoriesFromCargoLock(r io.Reader) ([]*types.PackageRepositories, error) {
+	lock := &cargoLock{}
+	if _, err := toml.NewDecoder(r).Decode(lock); err != nil {
+		return nil, err
+	}
+
+	var pkgRepos []*types.PackageRepositories
+	for _, pkg := range lock.Packages {
+		// Dependencies fetched from git have a source like
+		// git+https://github.com/foo/bar?branch=main#<commit>
+		var vcsURL string
+		if strings.HasPrefix(pkg.Source, "git+") {
+			vcsURL = pkg.Source
+		}
+
+		pkgRepo, err := packageRepositoriesFromLockfileEntry("cargo", "", pkg.Name, pkg.Version, vcsURL)
+		if err != nil {
+			return nil, err
+		}
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+	}
+
+	return pkgRepos, nil
+}
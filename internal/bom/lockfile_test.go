@@ -0,0 +1,399 @@
+package bom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestPackageRepositoriesFromLockfile(t *testing.T) {
+	testCases := map[string]struct {
+		path         string
+		wantPackages []*types.PackageRepositories
+		wantErr      error
+	}{
+		"go.mod": {
+			path: "testdata/lockfiles/go.mod",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "github.com/foo/bar",
+						Version: "v1.2.3",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "golang.org/x/sync",
+						Version: "v0.3.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/golang/sync",
+						},
+					},
+				},
+			},
+		},
+		"go.sum": {
+			path: "testdata/lockfiles/go.sum",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "github.com/foo/bar",
+						Version: "v1.2.3",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "golang.org/x/sync",
+						Version: "v0.3.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/golang/sync",
+						},
+					},
+				},
+			},
+		},
+		"package-lock.json": {
+			path: "testdata/lockfiles/package-lock.json",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "@babel/code-frame",
+						Version: "7.22.5",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/babel/babel",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "zwitch",
+						Version: "1.0.0",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "zwitch",
+						Version: "2.0.2",
+					},
+				},
+			},
+		},
+		"yarn.lock": {
+			path: "testdata/lockfiles/yarn.lock",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "@babel/code-frame",
+						Version: "7.22.5",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/babel/babel",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "zwitch",
+						Version: "2.0.2",
+					},
+				},
+			},
+		},
+		"Cargo.lock": {
+			path: "testdata/lockfiles/Cargo.lock",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "cargo",
+						Name:    "foo",
+						Version: "0.1.0",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "cargo",
+						Name:    "getrandom",
+						Version: "0.2.7",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "cargo",
+						Name:    "bar",
+						Version: "0.3.0",
+						Qualifiers: map[string]string{
+							"vcs_url": "git+https://github.com/foo/bar",
+						},
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+			},
+		},
+		"poetry.lock": {
+			path: "testdata/lockfiles/poetry.lock",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "pypi",
+						Name:    "zope.interface",
+						Version: "5.4.0",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "pypi",
+						Name:    "bar",
+						Version: "0.3.0",
+						Qualifiers: map[string]string{
+							"vcs_url": "https://github.com/foo/bar.git",
+						},
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+			},
+		},
+		"requirements.txt": {
+			path: "testdata/lockfiles/requirements.txt",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "pypi",
+						Name:    "zope.interface",
+						Version: "5.4.0",
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "pypi",
+						Name: "requests",
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "pypi",
+						Name: "bar",
+						Qualifiers: map[string]string{
+							"vcs_url": "git+https://github.com/foo/bar.git",
+						},
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "pypi",
+						Name: "baz",
+						Qualifiers: map[string]string{
+							"vcs_url": "git+https://github.com/foo/baz.git",
+						},
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/baz",
+						},
+					},
+				},
+			},
+		},
+		"unsupported files return ErrUnsupportedLockfile": {
+			path:    "testdata/cdx.json",
+			wantErr: ErrUnsupportedLockfile,
+		},
+		"Chart.yaml": {
+			path: "testdata/lockfiles/Chart.yaml",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "helm",
+						Name:    "foo",
+						Version: "1.2.3",
+					},
+					Repositories: []types.Repository{
+						{
+							Name:    "github.com/foo/charts",
+							Subpath: "charts/foo",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "helm",
+						Name: "postgresql",
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "helm",
+						Name: "bar",
+					},
+				},
+			},
+		},
+		"Chart.lock": {
+			path: "testdata/lockfiles/Chart.lock",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "helm",
+						Name:    "postgresql",
+						Version: "12.5.6",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "helm",
+						Name:    "bar",
+						Version: "0.1.0",
+					},
+				},
+			},
+		},
+		".pre-commit-config.yaml": {
+			path: "testdata/lockfiles/.pre-commit-config.yaml",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "pre-commit",
+						Name:    "github.com/pre-commit/pre-commit-hooks",
+						Version: "v4.4.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/pre-commit/pre-commit-hooks",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "pre-commit",
+						Name:    "gitlab.com/foo/hooks/lint",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "gitlab.com/foo/hooks/lint",
+						},
+					},
+				},
+			},
+		},
+		".gitmodules skips relative urls": {
+			path: "testdata/lockfiles/.gitmodules",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "git-submodule",
+						Name: "github.com/foo/bar",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+			},
+		},
+		"directories without lockfiles return ErrUnsupportedLockfile": {
+			path:    "testdata/scan/..",
+			wantErr: ErrUnsupportedLockfile,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			gotPackages, err := PackageRepositoriesFromLockfile(tc.path)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantPackages, gotPackages); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPackageRepositoriesFromLockfileDirectory(t *testing.T) {
+	gotPackages, err := PackageRepositoriesFromLockfile("testdata/lockfiles")
+	if err != nil {
+		t.Fatalf("unexpected error getting packages from lockfiles: %s", err)
+	}
+
+	// Packages should be merged across all the lockfiles in the directory
+	gotNames := map[string]int{}
+	for _, pkgRepo := range gotPackages {
+		gotNames[pkgRepo.Type+"/"+pkgRepo.Name]++
+	}
+	wantNames := map[string]int{
+		"golang/github.com/foo/bar": 1,
+		"golang/golang.org/x/sync":  1,
+		"npm/@babel/code-frame":     1,
+		"npm/zwitch":                2,
+		"cargo/foo":                 1,
+		"cargo/getrandom":           1,
+		"cargo/bar":                 1,
+		"pypi/zope.interface":       1,
+		"pypi/bar":                  2,
+		"pypi/requests":             1,
+		"pypi/baz":                  1,
+		"helm/foo":                  1,
+		"helm/postgresql":           1,
+		"helm/bar":                  1,
+		"pre-commit/github.com/pre-commit/pre-commit-hooks": 1,
+		"pre-commit/gitlab.com/foo/hooks/lint":              1,
+		"git-submodule/github.com/foo/bar":                  1,
+	}
+	if diff := cmp.Diff(wantNames, gotNames); diff != "" {
+		t.Errorf("unexpected packages:\n%s", diff)
+	}
+}
+
+func TestTrimVCSURL(t *testing.T) {
+	testCases := map[string]string{
+		"git+https://github.com/foo/bar":                                   "git+https://github.com/foo/bar",
+		"git+https://github.com/foo/bar?branch=main#6d9a552f0206a1db7feb4": "git+https://github.com/foo/bar",
+		"git+https://github.com/foo/bar.git#egg=bar":                       "git+https://github.com/foo/bar.git",
+	}
+	for vcsURL, want := range testCases {
+		if got := trimVCSURL(vcsURL); got != want {
+			t.Errorf("unexpected url for %s; wanted %s but got %s", vcsURL, want, got)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package bom
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// mappingsVersion is the version of the mappings file format that is
+// supported
+const mappingsVersion = 1
+
+// ErrUnsupportedMappingsVersion is returned when the mappings file has a version
+// that isn't supported
+var ErrUnsupportedMappingsVersion = errors.New("unsupported mappings version")
+
+//go:embed mappings.yaml
+var defaultMappingsData []byte
+
+// defaultMappings are the mappings that are embedded in the binary
+var defaultMappings = mustParseMappings(defaultMappingsData)
+
+type mappings struct {
+	Version int           `yaml:"version"`
+	Rules   []mappingRule `yaml:"rules"`
+}
+
+type mappingRule struct {
+	Type       string `yaml:"type"`
+	Prefix     string `yaml:"prefix"`
+	Regex      string `yaml:"regex"`
+	Repository string `yaml:"repository"`
+
+	regex *regexp.Regexp
+}
+
+func parseMappings(data []byte) (*mappings, error) {
+	m := &mappings{}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("decoding mappings: %w", err)
+	}
+	if m.Version != mappingsVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedMappingsVersion, m.Version)
+	}
+	for i, rule := range m.Rules {
+		if rule.Type == "" || rule.Repository == "" {
+			return nil, fmt.Errorf("rule %d: type and repository must be set", i)
+		}
+		if (rule.Prefix == "") == (rule.Regex == "") {
+			return nil, fmt.Errorf("rule %d: exactly one of prefix or regex must be set", i)
+		}
+		if rule.Regex == "" {
+			continue
+		}
+		re, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: compiling regex: %w", i, err)
+		}
+		m.Rules[i].regex = re
+	}
+
+	return m, nil
+}
+
+func mustParseMappings(data []byte) *mappings {
+	m, err := parseMappings(data)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// repository returns the repository for the package from the first rule that
+// matches it
+func (m *mappings) repository(pkg types.Package) *types.Repository {
+	for _, rule := range m.Rules {
+		if rule.Type != pkg.Type {
+			continue
+		}
+
+		var name string
+		switch {
+		case rule.regex != nil:
+			match := rule.regex.FindStringSubmatchIndex(pkg.Name)
+			if match == nil {
+				continue
+			}
+			name = string(rule.regex.ExpandString(nil, rule.Repository, pkg.Name, match))
+		case hasPathPrefix(pkg.Name, rule.Prefix):
+			name = rule.Repository + strings.TrimPrefix(pkg.Name, rule.Prefix)
+		default:
+			continue
+		}
+
+		// Repositories are in the format <platform>/<org>/<repo>
+		parts := strings.Split(name, "/")
+		if len(parts) < 3 {
+			continue
+		}
+
+		return &types.Repository{
+			Name: strings.Join(parts[:3], "/"),
+		}
+	}
+
+	return nil
+}
+
+// hasPathPrefix reports whether the prefix matches the name. Prefixes that
+// don't end with a / must match whole path elements, so that foo/bar matches
+// foo/bar/baz but not foo/barbaz.
+func hasPathPrefix(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+
+	return strings.HasSuffix(prefix, "/") || len(name) == len(prefix) || name[len(prefix)] == '/'
+}
@@ -0,0 +1,162 @@
+package bom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestDefaultMappings(t *testing.T) {
+	testCases := []struct {
+		pkg      types.Package
+		wantRepo *types.Repository
+	}{
+		{
+			pkg: types.Package{
+				Type: "golang",
+				Name: "k8s.io/api",
+			},
+			wantRepo: &types.Repository{
+				Name: "github.com/kubernetes/api",
+			},
+		},
+		{
+			pkg: types.Package{
+				Type: "golang",
+				Name: "golang.org/x/net/http2",
+			},
+			wantRepo: &types.Repository{
+				Name: "github.com/golang/net",
+			},
+		},
+		{
+			pkg: types.Package{
+				Type: "golang",
+				Name: "gopkg.in/yaml.v3",
+			},
+			wantRepo: &types.Repository{
+				Name: "github.com/go-yaml/yaml",
+			},
+		},
+		{
+			pkg: types.Package{
+				Type: "golang",
+				Name: "gopkg.in/DataDog/dd-trace-go.v1/ddtrace",
+			},
+			wantRepo: &types.Repository{
+				Name: "github.com/DataDog/dd-trace-go",
+			},
+		},
+		{
+			pkg: types.Package{
+				Type: "golang",
+				Name: "google.golang.org/grpc",
+			},
+			wantRepo: &types.Repository{
+				Name: "github.com/grpc/grpc-go",
+			},
+		},
+		{
+			pkg: types.Package{
+				Type: "golang",
+				Name: "google.golang.org/grpc/credentials",
+			},
+			wantRepo: &types.Repository{
+				Name: "github.com/grpc/grpc-go",
+			},
+		},
+		{
+			pkg: types.Package{
+				Type: "golang",
+				Name: "google.golang.org/grpcfoo",
+			},
+		},
+		{
+			pkg: types.Package{
+				Type: "npm",
+				Name: "@types/node",
+			},
+			wantRepo: &types.Repository{
+				Name: "github.com/DefinitelyTyped/DefinitelyTyped",
+			},
+		},
+		{
+			pkg: types.Package{
+				Type: "npm",
+				Name: "k8s.io/api",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.pkg.Type+"/"+tc.pkg.Name, func(t *testing.T) {
+			gotRepo := defaultMappings.repository(tc.pkg)
+			if diff := cmp.Diff(tc.wantRepo, gotRepo); diff != "" {
+				t.Errorf("unexpected repository:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseMappings(t *testing.T) {
+	testCases := map[string]struct {
+		data    string
+		wantErr error
+	}{
+		"valid mappings are parsed": {
+			data: `
+version: 1
+rules:
+  - type: golang
+    prefix: example.com/
+    repository: github.com/example/
+`,
+		},
+		"unsupported versions return ErrUnsupportedMappingsVersion": {
+			data: `
+version: 2
+rules: []
+`,
+			wantErr: ErrUnsupportedMappingsVersion,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			if _, err := parseMappings([]byte(tc.data)); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+
+	invalid := map[string]string{
+		"rules with both a prefix and a regex": `
+version: 1
+rules:
+  - type: golang
+    prefix: example.com/
+    regex: ^example\.com/(.*)$
+    repository: github.com/example/$1
+`,
+		"rules with an invalid regex": `
+version: 1
+rules:
+  - type: golang
+    regex: ^example\.com/(.*$
+    repository: github.com/example/$1
+`,
+		"rules without a repository": `
+version: 1
+rules:
+  - type: golang
+    prefix: example.com/
+`,
+	}
+	for n, data := range invalid {
+		t.Run(n, func(t *testing.T) {
+			if _, err := parseMappings([]byte(data)); err == nil {
+				t.Fatalf("expected error parsing mappings")
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package bom
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	ociIndexFile = "index.json"
+	ociBlobsDir  = "blobs"
+
+	ociAnnotationPredicateType = "in-toto.io/predicate-type"
+
+	dockerMediaTypeManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	dockerMediaTypeManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// ErrNoAttestations is returned when an image doesn't have any SBOM
+// attestations
+var ErrNoAttestations = errors.New("no SBOM attestations found")
+
+// PackageRepositoriesFromOCI discovers packages in the SBOM attestations that
+// are attached to the images in an OCI image layout, like those created by
+// BuildKit. The path can be a layout directory or a tarball of one, like those
+// created by `docker save`.
+func PackageRepositoriesFromOCI(p string) ([]*types.PackageRepositories, error) {
+	fi, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	var fsys fs.FS = &tarFS{path: p}
+	if fi.IsDir() {
+		fsys = os.DirFS(p)
+	}
+
+	data, err := fs.ReadFile(fsys, ociIndexFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%s not found, is this an OCI image layout?: %w", ociIndexFile, ErrNoAttestations)
+		}
+		return nil, fmt.Errorf("reading %s: %w", ociIndexFile, err)
+	}
+	index := &ocispec.Index{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", ociIndexFile, err)
+	}
+
+	w := &ociWalker{
+		fsys: fsys,
+		seen: map[string]struct{}{},
+	}
+	if err := w.walkDescriptors(index.Manifests); err != nil {
+		return nil, err
+	}
+	if w.attestations == 0 {
+		return nil, ErrNoAttestations
+	}
+
+	return w.pkgRepos, nil
+}
+
+type ociWalker struct {
+	fsys         fs.FS
+	seen         map[string]struct{}
+	attestations int
+	pkgRepos     []*types.PackageRepositories
+}
+
+func (w *ociWalker) walkDescriptors(descs []ocispec.Descriptor) error {
+	for _, desc := range descs {
+		if _, ok := w.seen[desc.Digest.String()]; ok {
+			continue
+		}
+		w.seen[desc.Digest.String()] = struct{}{}
+
+		switch desc.MediaType {
+		case ocispec.MediaTypeImageIndex, dockerMediaTypeManifestList:
+			index := &ocispec.Index{}
+			if err := w.readBlob(desc, index); err != nil {
+				return err
+			}
+			if err := w.walkDescriptors(index.Manifests); err != nil {
+				return err
+			}
+		case ocispec.MediaTypeImageManifest, dockerMediaTypeManifest:
+			manifest := &ocispec.Manifest{}
+			if err := w.readBlob(desc, manifest); err != nil {
+				return err
+			}
+			if err := w.walkLayers(manifest.Layers); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkLayers parses the SBOMs in any layers that are in-toto attestations
+func (w *ociWalker) walkLayers(layers []ocispec.Descriptor) error {
+	for _, layer := range layers {
+		predicateType := layer.Annotations[ociAnnotationPredicateType]
+		if !strings.HasPrefix(predicateType, predicateTypeCycloneDX) && !strings.HasPrefix(predicateType, predicateTypeSPDX) {
+			continue
+		}
+
+		f, err := w.fsys.Open(blobPath(layer))
+		if err != nil {
+			return fmt.Errorf("opening attestation %s: %w", layer.Digest, err)
+		}
+		pkgRepos, err := PackageRepositoriesFromBOM(f, FormatInToto)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("attestation %s: %w", layer.Digest, err)
+		}
+
+		w.attestations++
+		w.pkgRepos = MergePackageRepositories(w.pkgRepos, pkgRepos...)
+	}
+
+	return nil
+}
+
+func (w *ociWalker) readBlob(desc ocispec.Descriptor, v any) error {
+	data, err := fs.ReadFile(w.fsys, blobPath(desc))
+	if err != nil {
+		return fmt.Errorf("reading blob %s: %w", desc.Digest, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding blob %s: %w", desc.Digest, err)
+	}
+
+	return nil
+}
+
+func blobPath(desc ocispec.Descriptor) string {
+	return path.Join(ociBlobsDir, desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+}
+
+// tarFS is a minimal fs.FS over the regular files in a tarball. Each file is
+// found by scanning the tarball from the start, which avoids holding image
+// layers in memory.
+type tarFS struct {
+	path string
+}
+
+func (t *tarFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("reading tarball: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || path.Clean(strings.TrimPrefix(hdr.Name, "./")) != name {
+			continue
+		}
+
+		return &tarFile{
+			Closer: f,
+			Reader: tr,
+			hdr:    hdr,
+		}, nil
+	}
+	f.Close()
+
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+type tarFile struct {
+	io.Closer
+	io.Reader
+	hdr *tar.Header
+}
+
+func (f *tarFile) Stat() (fs.FileInfo, error) {
+	return f.hdr.FileInfo(), nil
+}
@@ -0,0 +1,104 @@
+package bom
+
+import (
+	"archive/tar"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestPackageRepositoriesFromOCI(t *testing.T) {
+	tarball := filepath.Join(t.TempDir(), "image.tar")
+	if err := writeTarball(tarball, "testdata/oci"); err != nil {
+		t.Fatalf("unexpected error writing tarball: %s", err)
+	}
+
+	wantPackages := []*types.PackageRepositories{
+		{
+			Package: types.Package{
+				Type:    "golang",
+				Name:    "github.com/foo/bar",
+				Version: "v0.2.5",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		path         string
+		wantPackages []*types.PackageRepositories
+		wantErr      error
+	}{
+		"oci layout directory": {
+			path:         "testdata/oci",
+			wantPackages: wantPackages,
+		},
+		"tarball": {
+			path:         tarball,
+			wantPackages: wantPackages,
+		},
+		"directories that aren't an oci layout return ErrNoAttestations": {
+			path:    "testdata/lockfiles",
+			wantErr: ErrNoAttestations,
+		},
+		"missing paths return fs.ErrNotExist": {
+			path:    "testdata/foo",
+			wantErr: fs.ErrNotExist,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			gotPackages, err := PackageRepositoriesFromOCI(tc.path)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantPackages, gotPackages); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+		})
+	}
+}
+
+func writeTarball(dst, src string) error {
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	defer tw.Close()
+
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name: filepath.ToSlash(name),
+			Mode: 0o644,
+			Size: int64(len(data)),
+		}); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+
+		return err
+	})
+}
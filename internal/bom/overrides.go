@@ -0,0 +1,144 @@
+package bom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
+	"gopkg.in/yaml.v3"
+)
+
+// overridesVersion is the version of the overrides file format that is
+// supported
+const overridesVersion = 1
+
+// ErrUnsupportedOverridesVersion is returned when the overrides file has a
+// version that isn't supported
+var ErrUnsupportedOverridesVersion = errors.New("unsupported overrides file version")
+
+// Overrides pin packages to repositories, taking precedence over the
+// repositories discovered in the BOM
+type Overrides struct {
+	Version   int        `yaml:"version"`
+	Overrides []Override `yaml:"overrides"`
+}
+
+// Override pins the packages that match the type, name and version to the
+// given repositories. If NoRepository is set then matching packages aren't
+// associated with any repository.
+type Override struct {
+	// Type is the purl type of the package. All types match when it is
+	// empty.
+	Type string `yaml:"type"`
+
+	// Name is a glob that matches the package name, where * matches any
+	// sequence of characters, including /
+	Name string `yaml:"name"`
+
+	// Version is a glob that matches the package version. All versions
+	// match when it is empty.
+	Version string `yaml:"version"`
+
+	Repositories []string `yaml:"repositories"`
+	NoRepository bool     `yaml:"noRepository"`
+
+	name    *regexp.Regexp
+	version *regexp.Regexp
+}
+
+// ParseOverrides parses overrides in YAML or JSON format
+func ParseOverrides(r io.Reader) (*Overrides, error) {
+	o := &Overrides{}
+	if err := yaml.NewDecoder(r).Decode(o); err != nil {
+		return nil, fmt.Errorf("decoding overrides: %w", err)
+	}
+	if o.Version != overridesVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedOverridesVersion, o.Version)
+	}
+	for i, override := range o.Overrides {
+		if override.Name == "" {
+			return nil, fmt.Errorf("override %d: name must be set", i)
+		}
+		if (len(override.Repositories) == 0) == !override.NoRepository {
+			return nil, fmt.Errorf("override %d: exactly one of repositories or noRepository must be set", i)
+		}
+		o.Overrides[i].name = globRegex(override.Name)
+		if override.Version != "" {
+			o.Overrides[i].version = globRegex(override.Version)
+		}
+	}
+
+	return o, nil
+}
+
+// Apply replaces the repositories of the packages that match an override. The
+// first matching override is used. It returns the packages that didn't match
+// any override.
+func (o *Overrides) Apply(pkgRepos ...*types.PackageRepositories) []*types.PackageRepositories {
+	var remaining []*types.PackageRepositories
+	for _, pkgRepo := range pkgRepos {
+		override := o.match(pkgRepo.Package)
+		if override == nil {
+			remaining = append(remaining, pkgRepo)
+			continue
+		}
+
+		pkgRepo.Repositories = nil
+		for _, repo := range override.Repositories {
+			pkgRepo.AddRepositories(overrideRepository(repo))
+		}
+	}
+
+	return remaining
+}
+
+// overrideRepository parses a repository in an override, which can be a url
+// (i.e https://github.com/foo/bar or git@github.com:foo/bar.git) or a name on a
+// host that isn't known. Repositories can include the subpath of the package,
+// i.e github.com/foo/bar#packages/baz.
+func overrideRepository(repo string) types.Repository {
+	name, subpath, _ := strings.Cut(repo, "#")
+	r := vcs_url.ToRepository(name)
+	if r == nil {
+		r = &types.Repository{
+			Name: vcs_url.Canonical(name),
+		}
+	}
+	if subpath != "" {
+		r.Subpath = strings.Trim(subpath, "/")
+	}
+
+	return *r
+}
+
+func (o *Overrides) match(pkg types.Package) *Override {
+	for i, override := range o.Overrides {
+		if override.Type != "" && override.Type != pkg.Type {
+			continue
+		}
+		if !override.name.MatchString(pkg.Name) {
+			continue
+		}
+		if override.version != nil && !override.version.MatchString(pkg.Version) {
+			continue
+		}
+
+		return &o.Overrides[i]
+	}
+
+	return nil
+}
+
+// globRegex converts a glob, where * matches any sequence of characters and ?
+// matches a single character, into a regex that matches the whole string
+func globRegex(glob string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(glob)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+
+	return regexp.MustCompile("^" + expr + "$")
+}
@@ -0,0 +1,205 @@
+package bom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestOverrides(t *testing.T) {
+	newPkgRepos := func() []*types.PackageRepositories {
+		return []*types.PackageRepositories{
+			{
+				Package: types.Package{
+					Type:    "golang",
+					Name:    "example.com/foo/bar",
+					Version: "v1.0.0",
+				},
+				Repositories: []types.Repository{
+					{
+						Name: "github.com/wrong/bar",
+					},
+				},
+			},
+			{
+				Package: types.Package{
+					Type:    "npm",
+					Name:    "@example/foo",
+					Version: "2.0.0",
+				},
+			},
+			{
+				Package: types.Package{
+					Type:    "npm",
+					Name:    "@example/foo",
+					Version: "1.0.0",
+				},
+			},
+			{
+				Package: types.Package{
+					Type: "pypi",
+					Name: "foo",
+				},
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		data          string
+		wantPkgRepos  []*types.PackageRepositories
+		wantRemaining int
+		wantErr       error
+	}{
+		"yaml overrides are applied": {
+			data: `
+version: 1
+overrides:
+  - type: golang
+    name: example.com/*
+    repositories:
+      - github.com/example/bar
+      - github.com/example/baz#foo/bar
+  - type: npm
+    name: "@example/*"
+    version: 1.*
+    noRepository: true
+  - name: "@example/*"
+    repositories:
+      - github.com/example/foo
+`,
+			wantPkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "example.com/foo/bar",
+						Version: "v1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/example/bar",
+						},
+						{
+							Name:    "github.com/example/baz",
+							Subpath: "foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "@example/foo",
+						Version: "2.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/example/foo",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "@example/foo",
+						Version: "1.0.0",
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "pypi",
+						Name: "foo",
+					},
+				},
+			},
+			wantRemaining: 1,
+		},
+		"json overrides are applied": {
+			data: `{"version": 1, "overrides": [{"type": "pypi", "name": "fo?", "repositories": ["github.com/example/foo"]}]}`,
+			wantPkgRepos: func() []*types.PackageRepositories {
+				pkgRepos := newPkgRepos()
+				pkgRepos[3].Repositories = []types.Repository{
+					{
+						Name: "github.com/example/foo",
+					},
+				}
+				return pkgRepos
+			}(),
+			wantRemaining: 3,
+		},
+		"repository urls are parsed": {
+			data: `
+version: 1
+overrides:
+  - type: golang
+    name: example.com/*
+    repositories:
+      - https://github.com/Example/Bar
+      - git@github.com:example/baz.git#foo/bar
+      - https://gitlab.com/example/group/qux/-/tree/main/foo
+      - git.example.com/example/quux
+`,
+			wantPkgRepos: func() []*types.PackageRepositories {
+				pkgRepos := newPkgRepos()
+				pkgRepos[0].Repositories = []types.Repository{
+					{
+						Name: "github.com/example/bar",
+					},
+					{
+						Name:    "github.com/example/baz",
+						Subpath: "foo/bar",
+					},
+					{
+						Name:    "gitlab.com/example/group/qux",
+						Subpath: "foo",
+					},
+					{
+						Name: "git.example.com/example/quux",
+					},
+				}
+				return pkgRepos
+			}(),
+			wantRemaining: 3,
+		},
+		"unsupported versions return ErrUnsupportedOverridesVersion": {
+			data:    `{"version": 2}`,
+			wantErr: ErrUnsupportedOverridesVersion,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			o, err := ParseOverrides(strings.NewReader(tc.data))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			pkgRepos := newPkgRepos()
+			remaining := o.Apply(pkgRepos...)
+			if diff := cmp.Diff(tc.wantPkgRepos, pkgRepos); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+			if len(remaining) != tc.wantRemaining {
+				t.Errorf("unexpected number of remaining packages; wanted %d but got %d", tc.wantRemaining, len(remaining))
+			}
+		})
+	}
+}
+
+func TestParseOverridesInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"overrides without a name":                       `{"version": 1, "overrides": [{"type": "npm", "repositories": ["github.com/foo/bar"]}]}`,
+		"overrides with repositories and noRepository":   `{"version": 1, "overrides": [{"name": "foo", "repositories": ["github.com/foo/bar"], "noRepository": true}]}`,
+		"overrides without repositories or noRepository": `{"version": 1, "overrides": [{"name": "foo"}]}`,
+	}
+	for n, data := range testCases {
+		t.Run(n, func(t *testing.T) {
+			if _, err := ParseOverrides(strings.NewReader(data)); err == nil {
+				t.Fatalf("expected error parsing overrides")
+			}
+		})
+	}
+}
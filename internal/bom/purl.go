@@ -1,45 +1,127 @@
 package bom
 
 import (
+	"regexp"
 	"strings"
 
-	github_url "github.com/jetstack/tally/internal/github-url"
 	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
 	"github.com/package-url/packageurl-go"
 )
 
+// purlTypeHosts are the hosts of the purl types that identify a repository
+var purlTypeHosts = map[string]string{
+	"github":    "github.com",
+	"bitbucket": "bitbucket.org",
+	"gitlab":    "gitlab.com",
+}
+
+// majorVersionRegex matches the major version suffix of a Go module path
+var majorVersionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
 func packageRepositoriesFromPurl(purl string) (*types.PackageRepositories, error) {
+	pkgRepo, err := packageRepositoriesFromPurlFields(purl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fallback to the well known mappings when the purl doesn't identify
+	// the repository
+	if len(pkgRepo.Repositories) == 0 {
+		if repo := defaultMappings.repository(pkgRepo.Package); repo != nil {
+			pkgRepo.AddRepositories(*repo)
+		}
+	}
+
+	return pkgRepo, nil
+}
+
+func packageRepositoriesFromPurlFields(purl string) (*types.PackageRepositories, error) {
 	p, err := packageurl.FromString(purl)
 	if err != nil {
 		return nil, err
 	}
 	pkgRepo := &types.PackageRepositories{
 		Package: types.Package{
-			Type: p.Type,
-			Name: p.Name,
+			Type:    p.Type,
+			Name:    p.Name,
+			Version: p.Version,
 		},
 	}
 	if p.Namespace != "" {
 		pkgRepo.Name = p.Namespace + "/" + p.Name
 	}
+	qualifiers := p.Qualifiers.Map()
+	if len(qualifiers) > 0 {
+		pkgRepo.Qualifiers = qualifiers
+	}
 
-	repo := github_url.ToRepository(p.Qualifiers.Map()["vcs_url"])
+	repo := vcs_url.ToRepository(qualifiers["vcs_url"])
 	if repo != nil {
 		pkgRepo.AddRepositories(*repo)
 	}
 
 	switch pkgRepo.Type {
 	case "golang":
-		if !strings.HasPrefix(pkgRepo.Name, "github.com/") {
-			return pkgRepo, nil
+		if repo := goModuleRepository(pkgRepo.Name); repo != nil {
+			pkgRepo.AddRepositories(*repo)
 		}
-		parts := strings.Split(pkgRepo.Name, "/")
-		if len(parts) < 3 {
-			return pkgRepo, nil
+	case "github", "bitbucket", "gitlab":
+		if repo := purlTypeRepository(pkgRepo.Type, pkgRepo.Name, p.Subpath); repo != nil {
+			pkgRepo.AddRepositories(*repo)
+		}
+	case "generic":
+		if repo := vcs_url.ToRepository(qualifiers["download_url"]); repo != nil {
+			pkgRepo.AddRepositories(*repo)
 		}
-
-		pkgRepo.AddRepositories(types.Repository{Name: strings.Join([]string{parts[0], parts[1], parts[2]}, "/")})
 	}
 
 	return pkgRepo, nil
 }
+
+// purlTypeRepository returns the repository identified by the name of a purl
+// with one of the purlTypeHosts types. GitHub and Bitbucket repositories are
+// always the first two elements of the name, so anything after them is part of
+// the subpath. GitLab projects can be nested in groups, so the whole name is
+// the repository.
+func purlTypeRepository(purlType, name, subpath string) *types.Repository {
+	parts := strings.Split(name, "/")
+	if len(parts) < 2 {
+		return nil
+	}
+	if purlType != "gitlab" {
+		parts, subpath = parts[:2], strings.Join(append(parts[2:], subpath), "/")
+	}
+
+	return &types.Repository{
+		Name:    vcs_url.Canonical(purlTypeHosts[purlType] + "/" + strings.Join(parts, "/")),
+		Subpath: strings.Trim(subpath, "/"),
+	}
+}
+
+// goModuleRepository returns the repository for a Go module that is hosted on
+// GitHub or Bitbucket, where the repository is always the first three
+// elements of the module path
+func goModuleRepository(path string) *types.Repository {
+	switch vcs_url.PlatformOf(path) {
+	case vcs_url.PlatformGitHub, vcs_url.PlatformBitbucket:
+	default:
+		return nil
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return nil
+	}
+
+	// Modules in the subdirectories of the repository have a subpath,
+	// excluding any major version suffix
+	subpath := parts[3:]
+	if len(subpath) > 0 && majorVersionRegex.MatchString(subpath[len(subpath)-1]) {
+		subpath = subpath[:len(subpath)-1]
+	}
+
+	return &types.Repository{
+		Name:    vcs_url.Canonical(strings.Join(parts[:3], "/")),
+		Subpath: strings.Join(subpath, "/"),
+	}
+}
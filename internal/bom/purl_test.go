@@ -18,8 +18,9 @@ func TestPackageRepositoriesFromPurl(t *testing.T) {
 			purl: "pkg:maven/org.hdrhistogram/HdrHistogram@2.1.9",
 			wantPackageRepositories: &types.PackageRepositories{
 				Package: types.Package{
-					Type: "maven",
-					Name: "org.hdrhistogram/HdrHistogram",
+					Type:    "maven",
+					Name:    "org.hdrhistogram/HdrHistogram",
+					Version: "2.1.9",
 				},
 			},
 		},
@@ -27,8 +28,14 @@ func TestPackageRepositoriesFromPurl(t *testing.T) {
 			purl: "pkg:golang/sigs.k8s.io/release-utils@v0.7.3",
 			wantPackageRepositories: &types.PackageRepositories{
 				Package: types.Package{
-					Type: "golang",
-					Name: "sigs.k8s.io/release-utils",
+					Type:    "golang",
+					Name:    "sigs.k8s.io/release-utils",
+					Version: "v0.7.3",
+				},
+				Repositories: []types.Repository{
+					{
+						Name: "github.com/kubernetes-sigs/release-utils",
+					},
 				},
 			},
 		},
@@ -36,8 +43,9 @@ func TestPackageRepositoriesFromPurl(t *testing.T) {
 			purl: "pkg:golang/github.com/foo/bar@v0.7.3",
 			wantPackageRepositories: &types.PackageRepositories{
 				Package: types.Package{
-					Type: "golang",
-					Name: "github.com/foo/bar",
+					Type:    "golang",
+					Name:    "github.com/foo/bar",
+					Version: "v0.7.3",
 				},
 				Repositories: []types.Repository{
 					{
@@ -46,12 +54,171 @@ func TestPackageRepositoriesFromPurl(t *testing.T) {
 				},
 			},
 		},
+		{
+			purl: "pkg:golang/github.com/foo/bar/baz/qux/v2@v2.0.0",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "golang",
+					Name:    "github.com/foo/bar/baz/qux/v2",
+					Version: "v2.0.0",
+				},
+				Repositories: []types.Repository{
+					{
+						Name:    "github.com/foo/bar",
+						Subpath: "baz/qux",
+					},
+				},
+			},
+		},
+		{
+			purl: "pkg:npm/foo@1.0.0?vcs_url=git%2Bhttps://github.com/foo/mono.git%23packages/foo",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "npm",
+					Name:    "foo",
+					Version: "1.0.0",
+					Qualifiers: map[string]string{
+						"vcs_url": "git+https://github.com/foo/mono.git#packages/foo",
+					},
+				},
+				Repositories: []types.Repository{
+					{
+						Name:    "github.com/foo/mono",
+						Subpath: "packages/foo",
+					},
+				},
+			},
+		},
+		{
+			purl: "pkg:github/Actions/Checkout@v4",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "github",
+					Name:    "actions/checkout",
+					Version: "v4",
+				},
+				Repositories: []types.Repository{
+					{
+						Name: "github.com/actions/checkout",
+					},
+				},
+			},
+		},
+		{
+			purl: "pkg:bitbucket/foo/bar@244fd47e07d1014f0aed9c#baz",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "bitbucket",
+					Name:    "foo/bar",
+					Version: "244fd47e07d1014f0aed9c",
+				},
+				Repositories: []types.Repository{
+					{
+						Name:    "bitbucket.org/foo/bar",
+						Subpath: "baz",
+					},
+				},
+			},
+		},
+		{
+			purl: "pkg:github/foo/bar/.github/workflows/x.yml@v1",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "github",
+					Name:    "foo/bar/.github/workflows/x.yml",
+					Version: "v1",
+				},
+				Repositories: []types.Repository{
+					{
+						Name:    "github.com/foo/bar",
+						Subpath: ".github/workflows/x.yml",
+					},
+				},
+			},
+		},
+		{
+			purl: "pkg:bitbucket/foo/bar/baz@v1#qux",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "bitbucket",
+					Name:    "foo/bar/baz",
+					Version: "v1",
+				},
+				Repositories: []types.Repository{
+					{
+						Name:    "bitbucket.org/foo/bar",
+						Subpath: "baz/qux",
+					},
+				},
+			},
+		},
+		{
+			purl: "pkg:gitlab/foo/bar/baz@v1.0.0",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "gitlab",
+					Name:    "foo/bar/baz",
+					Version: "v1.0.0",
+				},
+				Repositories: []types.Repository{
+					{
+						Name: "gitlab.com/foo/bar/baz",
+					},
+				},
+			},
+		},
+		{
+			purl: "pkg:github/foo",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type: "github",
+					Name: "foo",
+				},
+			},
+		},
+		{
+			purl: "pkg:generic/openssl@1.1.1w?download_url=https://github.com/openssl/openssl/archive/refs/tags/OpenSSL_1_1_1w.tar.gz",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "generic",
+					Name:    "openssl",
+					Version: "1.1.1w",
+					Qualifiers: map[string]string{
+						"download_url": "https://github.com/openssl/openssl/archive/refs/tags/OpenSSL_1_1_1w.tar.gz",
+					},
+				},
+				Repositories: []types.Repository{
+					{
+						Name: "github.com/openssl/openssl",
+					},
+				},
+			},
+		},
+		{
+			purl: "pkg:generic/foo@1.0.0?vcs_url=git%2Bhttps://gitlab.com/foo/bar/foo.git",
+			wantPackageRepositories: &types.PackageRepositories{
+				Package: types.Package{
+					Type:    "generic",
+					Name:    "foo",
+					Version: "1.0.0",
+					Qualifiers: map[string]string{
+						"vcs_url": "git+https://gitlab.com/foo/bar/foo.git",
+					},
+				},
+				Repositories: []types.Repository{
+					{
+						Name: "gitlab.com/foo/bar/foo",
+					},
+				},
+			},
+		},
 		{
 			purl: "pkg:npm/zwitch@2.0.2",
 			wantPackageRepositories: &types.PackageRepositories{
 				Package: types.Package{
-					Type: "npm",
-					Name: "zwitch",
+					Type:    "npm",
+					Name:    "zwitch",
+					Version: "2.0.2",
 				},
 			},
 		},
@@ -59,8 +226,9 @@ func TestPackageRepositoriesFromPurl(t *testing.T) {
 			purl: "pkg:cargo/getrandom@0.2.7",
 			wantPackageRepositories: &types.PackageRepositories{
 				Package: types.Package{
-					Type: "cargo",
-					Name: "getrandom",
+					Type:    "cargo",
+					Name:    "getrandom",
+					Version: "0.2.7",
 				},
 			},
 		},
@@ -68,8 +236,9 @@ func TestPackageRepositoriesFromPurl(t *testing.T) {
 			purl: "pkg:pypi/zope.interface@5.4.0",
 			wantPackageRepositories: &types.PackageRepositories{
 				Package: types.Package{
-					Type: "pypi",
-					Name: "zope.interface",
+					Type:    "pypi",
+					Name:    "zope.interface",
+					Version: "5.4.0",
 				},
 			},
 		},
@@ -77,8 +246,12 @@ func TestPackageRepositoriesFromPurl(t *testing.T) {
 			purl: "pkg:pypi/foo.bar@5.4.0?vcs_url=git+git+ssh://git@github.com:foo/bar.git#v5.4.0",
 			wantPackageRepositories: &types.PackageRepositories{
 				Package: types.Package{
-					Type: "pypi",
-					Name: "foo.bar",
+					Type:    "pypi",
+					Name:    "foo.bar",
+					Version: "5.4.0",
+					Qualifiers: map[string]string{
+						"vcs_url": "git+git+ssh://git@github.com:foo/bar.git",
+					},
 				},
 				Repositories: []types.Repository{
 					{
@@ -0,0 +1,85 @@
+package bom
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
+	spdx_json "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx/v2_3"
+)
+
+const (
+	spdxRefCategoryPackageManager = "PACKAGE-MANAGER"
+	spdxRefTypePurl               = "purl"
+
+	// SPDX 2.2 documents spell the package manager category with an
+	// underscore
+	spdxRefCategoryPackageManagerV2_2 = "PACKAGE_MANAGER"
+)
+
+// ParseSPDXJSONBOM parses an SPDX BOM in JSON format
+func ParseSPDXJSONBOM(r io.Reader) (*v2_3.Document, error) {
+	doc, err := spdx_json.Load2_3(r)
+	if err != nil {
+		return nil, fmt.Errorf("decoding spdx BOM: %w", err)
+	}
+
+	return doc, nil
+}
+
+// PackageRepositoriesFromSPDXBOM discovers packages in an SPDX BOM
+func PackageRepositoriesFromSPDXBOM(doc *v2_3.Document) ([]*types.PackageRepositories, error) {
+	var pkgRepos []*types.PackageRepositories
+	for _, pkg := range doc.Packages {
+		if pkg == nil {
+			continue
+		}
+		pkgRepo, err := packageRepositoriesFromSPDXPackage(pkg)
+		if err != nil {
+			return nil, err
+		}
+		if pkgRepo == nil {
+			continue
+		}
+
+		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+	}
+
+	return pkgRepos, nil
+}
+
+func packageRepositoriesFromSPDXPackage(pkg *v2_3.Package) (*types.PackageRepositories, error) {
+	var pkgRepo *types.PackageRepositories
+	for _, ref := range pkg.PackageExternalReferences {
+		if ref == nil || ref.RefType != spdxRefTypePurl {
+			continue
+		}
+		if ref.Category != spdxRefCategoryPackageManager && ref.Category != spdxRefCategoryPackageManagerV2_2 {
+			continue
+		}
+		p, err := packageRepositoriesFromPurl(ref.Locator)
+		if err != nil {
+			return nil, err
+		}
+		if pkgRepo == nil {
+			pkgRepo = p
+			continue
+		}
+		pkgRepo.AddRepositories(p.Repositories...)
+	}
+	if pkgRepo == nil {
+		return nil, nil
+	}
+
+	for _, u := range []string{pkg.PackageDownloadLocation, pkg.PackageHomePage} {
+		repo := vcs_url.ToRepository(u)
+		if repo == nil {
+			continue
+		}
+		pkgRepo.AddRepositories(*repo)
+	}
+
+	return pkgRepo, nil
+}
@@ -0,0 +1,19 @@
+package bom
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spdx/tools-golang/spdx/v2_3"
+	"github.com/spdx/tools-golang/tvloader"
+)
+
+// ParseSPDXTagValueBOM parses an SPDX BOM in tag-value format
+func ParseSPDXTagValueBOM(r io.Reader) (*v2_3.Document, error) {
+	doc, err := tvloader.Load2_3(r)
+	if err != nil {
+		return nil, fmt.Errorf("decoding spdx BOM: %w", err)
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,75 @@
+package bom
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestParseSPDXTagValueBOM(t *testing.T) {
+	testCases := map[string]struct {
+		path         string
+		wantPackages []*types.PackageRepositories
+		wantErr      bool
+	}{
+		"tag-value is parsed successfully": {
+			path: "testdata/spdx.spdx",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.2.5",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/hdrhistogram/hdrhistogram",
+						},
+					},
+				},
+			},
+		},
+		"error is returned when parsing invalid tag-value": {
+			path:    "testdata/spdx.spdx.invalid",
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			r, err := os.Open(tc.path)
+			if err != nil {
+				t.Fatalf("unexpected error opening file: %s", err)
+			}
+			defer r.Close()
+
+			gotBOM, err := ParseSPDXTagValueBOM(r)
+			if err != nil && !tc.wantErr {
+				t.Fatalf("unexpected error parsing BOM: %s", err)
+			}
+			if err == nil && tc.wantErr {
+				t.Fatalf("expected parsing BOM but got nil")
+			}
+
+			if tc.wantErr {
+				return
+			}
+
+			gotPackages, err := PackageRepositoriesFromSPDXBOM(gotBOM)
+			if err != nil {
+				t.Fatalf("unexpected error getting packages from bom: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantPackages, gotPackages); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package bom
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+	"github.com/spdx/tools-golang/spdx/v2_3"
+)
+
+func TestParseSPDXJSONBOM(t *testing.T) {
+	testCases := map[string]struct {
+		path         string
+		wantPackages []*types.PackageRepositories
+		wantErr      bool
+	}{
+		"json is parsed successfully": {
+			path: "testdata/spdx.json",
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "foo/bar",
+						Version: "v0.2.5",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/hdrhistogram/hdrhistogram",
+						},
+					},
+				},
+			},
+		},
+		"error is returned when parsing invalid json": {
+			path:    "testdata/spdx.json.invalid",
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			r, err := os.Open(tc.path)
+			if err != nil {
+				t.Fatalf("unexpected error opening file: %s", err)
+			}
+			defer r.Close()
+
+			gotBOM, err := ParseSPDXJSONBOM(r)
+			if err != nil && !tc.wantErr {
+				t.Fatalf("unexpected error parsing BOM: %s", err)
+			}
+			if err == nil && tc.wantErr {
+				t.Fatalf("expected parsing BOM but got nil")
+			}
+
+			if tc.wantErr {
+				return
+			}
+
+			gotPackages, err := PackageRepositoriesFromSPDXBOM(gotBOM)
+			if err != nil {
+				t.Fatalf("unexpected error getting packages from bom: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantPackages, gotPackages); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPackageRepositoriesFromSPDXBOM(t *testing.T) {
+	testCases := map[string]struct {
+		bom          *v2_3.Document
+		wantPackages []*types.PackageRepositories
+	}{
+		"an error should not be produced for an empty BOM": {
+			bom: &v2_3.Document{},
+		},
+		"packages without a purl should be ignored": {
+			bom: &v2_3.Document{
+				Packages: []*v2_3.Package{
+					{
+						PackageName:             "foo",
+						PackageDownloadLocation: "https://github.com/foo/bar",
+					},
+				},
+			},
+		},
+		"external references that aren't purls should be ignored": {
+			bom: &v2_3.Document{
+				Packages: []*v2_3.Package{
+					{
+						PackageExternalReferences: []*v2_3.PackageExternalReference{
+							{
+								Category: "SECURITY",
+								RefType:  "cpe23Type",
+								Locator:  "cpe:2.3:a:foo:bar:1.0.0:*:*:*:*:*:*:*",
+							},
+						},
+					},
+				},
+			},
+		},
+		"duplicate packages should be ignored": {
+			bom: &v2_3.Document{
+				Packages: []*v2_3.Package{
+					{
+						PackageExternalReferences: []*v2_3.PackageExternalReference{
+							{
+								Category: "PACKAGE-MANAGER",
+								RefType:  "purl",
+								Locator:  "pkg:maven/org.hdrhistogram/HdrHistogram@2.1.8",
+							},
+						},
+					},
+					{
+						PackageExternalReferences: []*v2_3.PackageExternalReference{
+							{
+								Category: "PACKAGE-MANAGER",
+								RefType:  "purl",
+								Locator:  "pkg:maven/org.hdrhistogram/HdrHistogram@2.1.9",
+							},
+						},
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.8",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
+					},
+				},
+			},
+		},
+		"should accept the SPDX 2.2 PACKAGE_MANAGER category": {
+			bom: &v2_3.Document{
+				Packages: []*v2_3.Package{
+					{
+						PackageExternalReferences: []*v2_3.PackageExternalReference{
+							{
+								Category: "PACKAGE_MANAGER",
+								RefType:  "purl",
+								Locator:  "pkg:npm/foobar@1.2.3",
+							},
+						},
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "foobar",
+						Version: "1.2.3",
+					},
+				},
+			},
+		},
+		"should discover repositories from downloadLocation and homepage": {
+			bom: &v2_3.Document{
+				Packages: []*v2_3.Package{
+					{
+						PackageDownloadLocation: "git+https://github.com/foo/bar.git",
+						PackageHomePage:         "https://github.com/bar/foo",
+						PackageExternalReferences: []*v2_3.PackageExternalReference{
+							{
+								Category: "PACKAGE-MANAGER",
+								RefType:  "purl",
+								Locator:  "pkg:npm/foobar@1.2.3",
+							},
+						},
+					},
+					{
+						PackageDownloadLocation: "NOASSERTION",
+						PackageHomePage:         "NONE",
+						PackageExternalReferences: []*v2_3.PackageExternalReference{
+							{
+								Category: "PACKAGE-MANAGER",
+								RefType:  "purl",
+								Locator:  "pkg:golang/github.com/foo/baz@v0.1.0",
+							},
+						},
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "foobar",
+						Version: "1.2.3",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+						{
+							Name: "github.com/bar/foo",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "github.com/foo/baz",
+						Version: "v0.1.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/baz",
+						},
+					},
+				},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			gotPackages, err := PackageRepositoriesFromSPDXBOM(tc.bom)
+			if err != nil {
+				t.Fatalf("unexpected error getting packages from bom: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantPackages, gotPackages); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+		})
+	}
+}
@@ -3,11 +3,13 @@ package bom
 import (
 	"encoding/json"
 	"io"
+	"strings"
 
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/formats/syftjson/model"
 	syft "github.com/anchore/syft/syft/pkg"
-	github_url "github.com/jetstack/tally/internal/github-url"
 	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
 )
 
 // ParseSyftBOM parses a syft SBOM
@@ -27,7 +29,11 @@ func ParseSyftBOM(r io.Reader) (*model.Document, error) {
 // PackageRepositoriesFromSyftBOM discovers packages in a Syft BOM
 func PackageRepositoriesFromSyftBOM(doc *model.Document) ([]*types.PackageRepositories, error) {
 	var pkgRepos []*types.PackageRepositories
+	refNames := map[string]string{}
+	refPkgs := map[string]types.Package{}
 	for _, a := range doc.Artifacts {
+		refNames[a.ID] = a.Name
+
 		pkgRepo, err := packageRepositoriesFromSyftPackage(a)
 		if err != nil {
 			return nil, err
@@ -35,21 +41,50 @@ func PackageRepositoriesFromSyftBOM(doc *model.Document) ([]*types.PackageReposi
 		if pkgRepo == nil {
 			continue
 		}
+		refPkgs[a.ID] = pkgRepo.Package
+		refNames[a.ID] = pkgRepo.Name
 
 		pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
 	}
 
+	// Syft doesn't identify a root package, so the packages that nothing
+	// else depends on are treated as the direct dependencies
+	setDependencies(syftDependencyGraph(doc), "", refNames, refPkgs, pkgRepos)
+
 	return pkgRepos, nil
 }
 
-func packageRepositoriesFromSyftPackage(pkg model.Package) (*types.PackageRepositories, error) {
-	if pkg.PURL == "" {
-		return nil, nil
+func syftDependencyGraph(doc *model.Document) *dependencyGraph {
+	graph := newDependencyGraph()
+	for _, rel := range doc.ArtifactRelationships {
+		if rel.Type != string(artifact.DependencyOfRelationship) {
+			continue
+		}
+
+		// The parent of a dependency-of relationship is the dependency
+		// of the child
+		graph.addEdge(rel.Child, rel.Parent)
 	}
 
-	pkgRepo, err := packageRepositoriesFromPurl(pkg.PURL)
-	if err != nil {
-		return nil, err
+	return graph
+}
+
+func packageRepositoriesFromSyftPackage(pkg model.Package) (*types.PackageRepositories, error) {
+	var pkgRepo *types.PackageRepositories
+	if pkg.PURL != "" {
+		var err error
+		pkgRepo, err = packageRepositoriesFromPurl(pkg.PURL)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Packages without a purl are identified by their name and
+		// CPE
+		var cpe string
+		if len(pkg.CPEs) > 0 {
+			cpe = pkg.CPEs[0]
+		}
+		pkgRepo = packageRepositoriesFromIdentity("", pkg.Name, pkg.Version, cpe)
 	}
 	if pkgRepo == nil {
 		return nil, nil
@@ -61,60 +96,104 @@ func packageRepositoriesFromSyftPackage(pkg model.Package) (*types.PackageReposi
 }
 
 func repositoriesFromSyftPackage(pkg model.Package) []types.Repository {
-	var repos []types.Repository
+	var urls []string
 	switch pkg.MetadataType {
+	case syft.AlpmMetadataType:
+		metadata, ok := pkg.Metadata.(syft.AlpmMetadata)
+		if ok {
+			urls = append(urls, metadata.URL)
+		}
+	case syft.ApkMetadataType:
+		metadata, ok := pkg.Metadata.(syft.ApkMetadata)
+		if ok {
+			urls = append(urls, metadata.URL)
+		}
 	case syft.DartPubMetadataType:
 		metadata, ok := pkg.Metadata.(syft.DartPubMetadata)
 		if ok {
-			repo := github_url.ToRepository(metadata.VcsURL)
-			if repo != nil {
-				repos = append(repos, *repo)
-			}
+			urls = append(urls, metadata.VcsURL)
 		}
 	case syft.GemMetadataType:
 		metadata, ok := pkg.Metadata.(syft.GemMetadata)
 		if ok {
-			repo := github_url.ToRepository(metadata.Homepage)
-			if repo != nil {
-				repos = append(repos, *repo)
+			urls = append(urls, metadata.Homepage)
+		}
+	case syft.GolangBinMetadataType, syft.GolangModMetadataType:
+		// The metadata doesn't include a url, but the module path
+		// identifies the repository on some hosts
+		if repo := goModuleRepository(pkg.Name); repo != nil {
+			return []types.Repository{*repo}
+		}
+	case syft.JavaMetadataType:
+		metadata, ok := pkg.Metadata.(syft.JavaMetadata)
+		if ok {
+			if metadata.PomProject != nil {
+				urls = append(urls, metadata.PomProject.URL)
+			}
+			if metadata.Manifest != nil {
+				for _, key := range javaManifestURLKeys {
+					urls = append(urls, metadata.Manifest.Main[key])
+				}
 			}
 		}
 	case syft.PhpComposerJSONMetadataType:
 		metadata, ok := pkg.Metadata.(syft.PhpComposerJSONMetadata)
 		if ok {
-			repo := github_url.ToRepository(metadata.Source.URL)
-			if repo != nil {
-				repos = append(repos, *repo)
-			}
+			urls = append(urls, metadata.Source.URL, metadata.Homepage)
 		}
 	case syft.NpmPackageJSONMetadataType:
 		metadata, ok := pkg.Metadata.(syft.NpmPackageJSONMetadata)
 		if ok {
-			repo := github_url.ToRepository(metadata.Homepage)
-			if repo != nil {
-				repos = append(repos, *repo)
-			}
-			repo = github_url.ToRepository(metadata.URL)
-			if repo != nil {
-				repos = append(repos, *repo)
-			}
+			urls = append(urls, metadata.Homepage, metadata.URL)
 		}
 	case syft.PythonPackageMetadataType:
 		metadata, ok := pkg.Metadata.(syft.PythonPackageMetadata)
 		if ok {
 			if metadata.DirectURLOrigin != nil {
-				repo := github_url.ToRepository(metadata.DirectURLOrigin.URL)
-				if repo != nil {
-					repos = append(repos, *repo)
-				}
+				urls = append(urls, metadata.DirectURLOrigin.URL)
 			}
 		}
+	case syft.PythonRequirementsMetadataType:
+		metadata, ok := pkg.Metadata.(syft.PythonRequirementsMetadata)
+		if ok {
+			urls = append(urls, metadata.URL)
+		}
+	case syft.RDescriptionFileMetadataType:
+		metadata, ok := pkg.Metadata.(syft.RDescriptionFileMetadata)
+		if ok {
+			urls = append(urls, metadata.URL...)
+		}
+	case syft.RustCargoPackageMetadataType:
+		metadata, ok := pkg.Metadata.(syft.CargoPackageMetadata)
+		if ok {
+			// Packages from a registry have the url of the
+			// registry index as their source, rather than their
+			// repository
+			if strings.HasPrefix(metadata.Source, "git+") {
+				urls = append(urls, metadata.Source)
+			}
+		}
+	}
 
+	var repos []types.Repository
+	for _, u := range urls {
+		repo := vcs_url.ToRepository(u)
+		if repo != nil {
+			repos = append(repos, *repo)
+		}
 	}
 
 	return repos
 }
 
+// javaManifestURLKeys are the attributes in a JAR manifest that may contain
+// the url of the project's repository
+var javaManifestURLKeys = []string{
+	"Bundle-SCM",
+	"Implementation-URL",
+	"Bundle-DocURL",
+}
+
 func appendPackageRepositories(pkgRepos []*types.PackageRepositories, pkgRepo *types.PackageRepositories) []*types.PackageRepositories {
 	for _, p := range pkgRepos {
 		if !p.Equals(pkgRepo.Package) {
@@ -122,6 +201,8 @@ func appendPackageRepositories(pkgRepos []*types.PackageRepositories, pkgRepo *t
 		}
 
 		p.AddRepositories(pkgRepo.Repositories...)
+		p.SetDependency(pkgRepo.Depth, pkgRepo.Path)
+		p.AddSources(pkgRepo.Sources...)
 
 		return pkgRepos
 	}
@@ -0,0 +1,167 @@
+package bom
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/formats/syftjson"
+	"github.com/anchore/syft/syft/formats/syftjson/model"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/alpm"
+	"github.com/anchore/syft/syft/pkg/cataloger/apkdb"
+	"github.com/anchore/syft/syft/pkg/cataloger/binary"
+	"github.com/anchore/syft/syft/pkg/cataloger/cpp"
+	"github.com/anchore/syft/syft/pkg/cataloger/dart"
+	"github.com/anchore/syft/syft/pkg/cataloger/deb"
+	"github.com/anchore/syft/syft/pkg/cataloger/dotnet"
+	"github.com/anchore/syft/syft/pkg/cataloger/elixir"
+	"github.com/anchore/syft/syft/pkg/cataloger/erlang"
+	"github.com/anchore/syft/syft/pkg/cataloger/golang"
+	"github.com/anchore/syft/syft/pkg/cataloger/haskell"
+	"github.com/anchore/syft/syft/pkg/cataloger/java"
+	"github.com/anchore/syft/syft/pkg/cataloger/javascript"
+	"github.com/anchore/syft/syft/pkg/cataloger/nix"
+	"github.com/anchore/syft/syft/pkg/cataloger/php"
+	"github.com/anchore/syft/syft/pkg/cataloger/portage"
+	"github.com/anchore/syft/syft/pkg/cataloger/python"
+	"github.com/anchore/syft/syft/pkg/cataloger/r"
+	"github.com/anchore/syft/syft/pkg/cataloger/rpm"
+	"github.com/anchore/syft/syft/pkg/cataloger/ruby"
+	"github.com/anchore/syft/syft/pkg/cataloger/rust"
+	"github.com/anchore/syft/syft/pkg/cataloger/swift"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// GenerateSyftBOM catalogs the packages in a local directory, image tarball
+// or OCI layout with syft and returns the result as a syft BOM. Catalogers that
+// fail are skipped, with a warning written to w.
+func GenerateSyftBOM(w io.Writer, path string) (*model.Document, error) {
+	// If the writer is nil then just discard anything we write
+	if w == nil {
+		w = io.Discard
+	}
+
+	// Only scan local sources. Syft will otherwise try to pull anything it
+	// doesn't recognise from a registry.
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	detection, err := source.Detect(path, source.DefaultDetectConfig())
+	if err != nil {
+		return nil, fmt.Errorf("detecting source type: %w", err)
+	}
+	src, err := detection.NewSource(source.DefaultDetectionSourceConfig())
+	if err != nil {
+		return nil, fmt.Errorf("creating source: %w", err)
+	}
+	defer src.Close()
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	if err != nil {
+		return nil, fmt.Errorf("creating file resolver: %w", err)
+	}
+
+	catalogers := syftDirectoryCatalogers()
+	if detection.IsContainerImage() {
+		catalogers = syftImageCatalogers()
+	}
+
+	collection, relationships := catalogPackages(w, resolver, catalogers...)
+
+	doc := syftjson.ToFormatModel(sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			Packages:          collection,
+			LinuxDistribution: linux.IdentifyRelease(resolver),
+		},
+		Relationships: relationships,
+		Source:        src.Describe(),
+	})
+
+	return &doc, nil
+}
+
+// catalogPackages runs each of the catalogers. One failing cataloger
+// shouldn't throw away the packages that the others found, so failures are
+// written to w as warnings.
+func catalogPackages(w io.Writer, resolver file.Resolver, catalogers ...pkg.Cataloger) (*pkg.Collection, []artifact.Relationship) {
+	collection := pkg.NewCollection()
+	var relationships []artifact.Relationship
+	for _, c := range catalogers {
+		pkgs, rels, err := c.Catalog(resolver)
+		if err != nil {
+			fmt.Fprintf(w, "Warning: cataloging packages with %s: %s\n", c.Name(), err)
+			continue
+		}
+		collection.Add(pkgs...)
+		relationships = append(relationships, rels...)
+	}
+	relationships = append(relationships, pkg.NewRelationships(collection)...)
+
+	return collection, relationships
+}
+
+// These mirror the default cataloger sets in syft's cataloger package, which
+// we can't use directly: its SBOM cataloger depends on a version of
+// github.com/spdx/tools-golang that is incompatible with the one required by
+// scorecard.
+
+func syftImageCatalogers() []pkg.Cataloger {
+	return []pkg.Cataloger{
+		alpm.NewAlpmdbCataloger(),
+		apkdb.NewApkdbCataloger(),
+		binary.NewCataloger(),
+		deb.NewDpkgdbCataloger(),
+		dotnet.NewDotnetPortableExecutableCataloger(),
+		golang.NewGoModuleBinaryCataloger(golang.NewGoCatalogerOpts()),
+		java.NewJavaCataloger(java.Config{SearchIndexedArchives: true}),
+		java.NewNativeImageCataloger(),
+		javascript.NewPackageCataloger(),
+		nix.NewStoreCataloger(),
+		php.NewComposerInstalledCataloger(),
+		portage.NewPortageCataloger(),
+		python.NewPythonPackageCataloger(),
+		r.NewPackageCataloger(),
+		rpm.NewRpmDBCataloger(),
+		ruby.NewGemSpecCataloger(),
+	}
+}
+
+func syftDirectoryCatalogers() []pkg.Cataloger {
+	return []pkg.Cataloger{
+		alpm.NewAlpmdbCataloger(),
+		apkdb.NewApkdbCataloger(),
+		binary.NewCataloger(),
+		cpp.NewConanCataloger(),
+		dart.NewPubspecLockCataloger(),
+		deb.NewDpkgdbCataloger(),
+		dotnet.NewDotnetDepsCataloger(),
+		dotnet.NewDotnetPortableExecutableCataloger(),
+		elixir.NewMixLockCataloger(),
+		erlang.NewRebarLockCataloger(),
+		golang.NewGoModFileCataloger(golang.NewGoCatalogerOpts()),
+		golang.NewGoModuleBinaryCataloger(golang.NewGoCatalogerOpts()),
+		haskell.NewHackageCataloger(),
+		java.NewJavaCataloger(java.Config{SearchIndexedArchives: true}),
+		java.NewJavaGradleLockfileCataloger(),
+		java.NewJavaPomCataloger(),
+		java.NewNativeImageCataloger(),
+		javascript.NewLockCataloger(),
+		nix.NewStoreCataloger(),
+		php.NewComposerLockCataloger(),
+		portage.NewPortageCataloger(),
+		python.NewPythonIndexCataloger(python.DefaultCatalogerConfig()),
+		python.NewPythonPackageCataloger(),
+		rpm.NewFileCataloger(),
+		rpm.NewRpmDBCataloger(),
+		ruby.NewGemFileLockCataloger(),
+		rust.NewCargoLockCataloger(),
+		swift.NewCocoapodsCataloger(),
+		swift.NewSwiftPackageManagerCataloger(),
+	}
+}
@@ -0,0 +1,93 @@
+package bom
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestGenerateSyftBOM(t *testing.T) {
+	doc, err := GenerateSyftBOM(nil, "testdata/scan")
+	if err != nil {
+		t.Fatalf("unexpected error generating BOM: %s", err)
+	}
+
+	gotPackages, err := PackageRepositoriesFromSyftBOM(doc)
+	if err != nil {
+		t.Fatalf("unexpected error getting packages from bom: %s", err)
+	}
+	wantPackages := []*types.PackageRepositories{
+		{
+			Package: types.Package{
+				Type:    "golang",
+				Name:    "github.com/foo/bar",
+				Version: "v1.2.3",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(wantPackages, gotPackages); diff != "" {
+		t.Errorf("unexpected packages:\n%s", diff)
+	}
+}
+
+func TestGenerateSyftBOMNotFound(t *testing.T) {
+	if _, err := GenerateSyftBOM(nil, "testdata/does-not-exist"); err == nil {
+		t.Fatalf("expected error for a path that doesn't exist")
+	}
+}
+
+type fakeCataloger struct {
+	name string
+	pkgs []pkg.Package
+	err  error
+}
+
+func (c *fakeCataloger) Name() string {
+	return c.name
+}
+
+func (c *fakeCataloger) Catalog(resolver file.Resolver) ([]pkg.Package, []artifact.Relationship, error) {
+	return c.pkgs, nil, c.err
+}
+
+func TestCatalogPackagesError(t *testing.T) {
+	var w bytes.Buffer
+	collection, _ := catalogPackages(
+		&w,
+		nil,
+		&fakeCataloger{
+			name: "failing-cataloger",
+			err:  errors.New("foo"),
+		},
+		&fakeCataloger{
+			name: "go-module-binary-cataloger",
+			pkgs: []pkg.Package{
+				{
+					Name:    "github.com/foo/bar",
+					Version: "v1.2.3",
+					Type:    pkg.GoModulePkg,
+				},
+			},
+		},
+	)
+
+	// The packages found by the other catalogers should be kept
+	if collection.PackageCount() != 1 {
+		t.Errorf("unexpected number of packages; wanted 1 but got %d", collection.PackageCount())
+	}
+	if !strings.Contains(w.String(), "failing-cataloger") {
+		t.Errorf("expected a warning for the failing cataloger but got %q", w.String())
+	}
+}
@@ -77,12 +77,55 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 		"an error should not be produced for an empty BOM": {
 			bom: &model.Document{},
 		},
-		"components without a PURL should be ignored": {
+		"components without a PURL are identified by their name": {
 			bom: &model.Document{
 				Artifacts: []model.Package{
 					{
 						PackageBasicData: model.PackageBasicData{
-							Name: "foo",
+							Name:    "foo",
+							Version: "1.0.0",
+						},
+						PackageCustomData: model.PackageCustomData{
+							MetadataType: pkg.GemMetadataType,
+							Metadata: pkg.GemMetadata{
+								Homepage: "https://github.com/foo/bar",
+							},
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							CPEs: []string{
+								"cpe:2.3:a:bar:baz:2.0.0:*:*:*:*:*:*:*",
+							},
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							Version: "3.0.0",
+						},
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "generic",
+						Name:    "foo",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "generic",
+						Name:    "bar/baz",
+						Version: "2.0.0",
+						Qualifiers: map[string]string{
+							"cpe": "cpe:2.3:a:bar:baz:2.0.0:*:*:*:*:*:*:*",
 						},
 					},
 				},
@@ -114,8 +157,16 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "maven",
-						Name: "org.hdrhistogram/HdrHistogram",
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.8",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
 					},
 				},
 			},
@@ -158,32 +209,236 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "maven",
-						Name: "org.hdrhistogram/HdrHistogram",
+						Type:    "maven",
+						Name:    "org.hdrhistogram/HdrHistogram",
+						Version: "2.1.9",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "golang",
+						Name:    "sigs.k8s.io/release-utils",
+						Version: "v0.7.3",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/kubernetes-sigs/release-utils",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "zwitch",
+						Version: "2.0.2",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "cargo",
+						Name:    "getrandom",
+						Version: "0.2.7",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "pypi",
+						Name:    "zope.interface",
+						Version: "5.4.0",
+					},
+				},
+			},
+		},
+		"should discover repositories from java, rust, go, R, apk and python requirements metadata": {
+			bom: &model.Document{
+				Artifacts: []model.Package{
+					{
+						PackageBasicData: model.PackageBasicData{
+							PURL: "pkg:maven/org.foo/bar@1.0.0",
+						},
+						PackageCustomData: model.PackageCustomData{
+							MetadataType: pkg.JavaMetadataType,
+							Metadata: pkg.JavaMetadata{
+								PomProject: &pkg.PomProject{
+									URL: "https://github.com/foo/bar",
+								},
+							},
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							PURL: "pkg:maven/org.foo/baz@1.0.0",
+						},
+						PackageCustomData: model.PackageCustomData{
+							MetadataType: pkg.JavaMetadataType,
+							Metadata: pkg.JavaMetadata{
+								Manifest: &pkg.JavaManifest{
+									Main: map[string]string{
+										"Bundle-SCM": "url=https://github.com/foo/baz,connection=scm:git:https://github.com/foo/baz.git",
+									},
+								},
+							},
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							PURL: "pkg:cargo/foo@1.0.0",
+						},
+						PackageCustomData: model.PackageCustomData{
+							MetadataType: pkg.RustCargoPackageMetadataType,
+							Metadata: pkg.CargoPackageMetadata{
+								Source: "git+https://github.com/foo/cargo?branch=main#6d9a552f0206a1db7feb442824540aa6c55e5b27",
+							},
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							PURL: "pkg:cargo/bar@1.0.0",
+						},
+						PackageCustomData: model.PackageCustomData{
+							MetadataType: pkg.RustCargoPackageMetadataType,
+							Metadata: pkg.CargoPackageMetadata{
+								Source: "registry+https://github.com/rust-lang/crates.io-index",
+							},
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							Name: "bitbucket.org/foo/bar/baz",
+						},
+						PackageCustomData: model.PackageCustomData{
+							MetadataType: pkg.GolangBinMetadataType,
+							Metadata:     pkg.GolangBinMetadata{},
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							PURL: "pkg:generic/r-foo@1.0.0",
+						},
+						PackageCustomData: model.PackageCustomData{
+							MetadataType: pkg.RDescriptionFileMetadataType,
+							Metadata: pkg.RDescriptionFileMetadata{
+								URL: []string{
+									"https://foo.example.com",
+									"https://github.com/foo/r-foo",
+								},
+							},
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							PURL: "pkg:apk/alpine/foo@1.0.0",
+						},
+						PackageCustomData: model.PackageCustomData{
+							MetadataType: pkg.ApkMetadataType,
+							Metadata: pkg.ApkMetadata{
+								URL: "https://gitlab.com/foo/apk",
+							},
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							PURL: "pkg:pypi/foo@1.0.0",
+						},
+						PackageCustomData: model.PackageCustomData{
+							MetadataType: pkg.PythonRequirementsMetadataType,
+							Metadata: pkg.PythonRequirementsMetadata{
+								URL: "git+https://github.com/foo/pypi.git@v1.0.0",
+							},
+						},
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "maven",
+						Name:    "org.foo/bar",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "maven",
+						Name:    "org.foo/baz",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/baz",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "cargo",
+						Name:    "foo",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/cargo",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "cargo",
+						Name:    "bar",
+						Version: "1.0.0",
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "golang",
-						Name: "sigs.k8s.io/release-utils",
+						Type: "generic",
+						Name: "bitbucket.org/foo/bar/baz",
+					},
+					Repositories: []types.Repository{
+						{
+							Name:    "bitbucket.org/foo/bar",
+							Subpath: "baz",
+						},
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "npm",
-						Name: "zwitch",
+						Type:    "generic",
+						Name:    "r-foo",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/r-foo",
+						},
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "cargo",
-						Name: "getrandom",
+						Type:    "apk",
+						Name:    "alpine/foo",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "gitlab.com/foo/apk",
+						},
 					},
 				},
 				{
 					Package: types.Package{
-						Type: "pypi",
-						Name: "zope.interface",
+						Type:    "pypi",
+						Name:    "foo",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/pypi",
+						},
 					},
 				},
 			},
@@ -300,8 +555,12 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 			wantPackages: []*types.PackageRepositories{
 				{
 					Package: types.Package{
-						Type: "pub",
-						Name: "foobar",
+						Type:    "pub",
+						Name:    "foobar",
+						Version: "3.3.0",
+						Qualifiers: map[string]string{
+							"hosted_url": "pub.hosted.org",
+						},
 					},
 					Repositories: []types.Repository{
 						{
@@ -311,8 +570,9 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 				},
 				{
 					Package: types.Package{
-						Type: "gem",
-						Name: "foobar",
+						Type:    "gem",
+						Name:    "foobar",
+						Version: "2.1.4",
 					},
 					Repositories: []types.Repository{
 						{
@@ -322,8 +582,9 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 				},
 				{
 					Package: types.Package{
-						Type: "composer",
-						Name: "foo/bar",
+						Type:    "composer",
+						Name:    "foo/bar",
+						Version: "1.0.2",
 					},
 					Repositories: []types.Repository{
 						{
@@ -333,8 +594,9 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 				},
 				{
 					Package: types.Package{
-						Type: "npm",
-						Name: "foobar",
+						Type:    "npm",
+						Name:    "foobar",
+						Version: "6.14.6",
 					},
 					Repositories: []types.Repository{
 						{
@@ -344,8 +606,9 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 				},
 				{
 					Package: types.Package{
-						Type: "npm",
-						Name: "foobar1",
+						Type:    "npm",
+						Name:    "foobar1",
+						Version: "6.14.6",
 					},
 					Repositories: []types.Repository{
 						{
@@ -355,8 +618,9 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 				},
 				{
 					Package: types.Package{
-						Type: "npm",
-						Name: "barfoo",
+						Type:    "npm",
+						Name:    "barfoo",
+						Version: "6.14.6",
 					},
 					Repositories: []types.Repository{
 						{
@@ -369,8 +633,9 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 				},
 				{
 					Package: types.Package{
-						Type: "pypi",
-						Name: "foobar",
+						Type:    "pypi",
+						Name:    "foobar",
+						Version: "v0.1.0",
 					},
 					Repositories: []types.Repository{
 						{
@@ -380,8 +645,12 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 				},
 				{
 					Package: types.Package{
-						Type: "pypi",
-						Name: "foo.bar",
+						Type:    "pypi",
+						Name:    "foo.bar",
+						Version: "5.4.0",
+						Qualifiers: map[string]string{
+							"vcs_url": "git+git+ssh://git@github.com:foo/bar.git",
+						},
 					},
 					Repositories: []types.Repository{
 						{
@@ -391,6 +660,79 @@ func TestPackageRepositoriesFromSyftBOM(t *testing.T) {
 				},
 			},
 		},
+		"dependency paths are discovered from artifact relationships": {
+			bom: &model.Document{
+				Artifacts: []model.Package{
+					{
+						PackageBasicData: model.PackageBasicData{
+							ID:   "0",
+							PURL: "pkg:npm/foo@1.0.0",
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							ID:   "1",
+							PURL: "pkg:npm/bar@1.0.0",
+						},
+					},
+					{
+						PackageBasicData: model.PackageBasicData{
+							ID:   "2",
+							PURL: "pkg:npm/baz@1.0.0",
+						},
+					},
+				},
+				ArtifactRelationships: []model.Relationship{
+					{
+						Parent: "1",
+						Child:  "0",
+						Type:   "dependency-of",
+					},
+					{
+						Parent: "2",
+						Child:  "1",
+						Type:   "dependency-of",
+					},
+					{
+						Parent: "0",
+						Child:  "2",
+						Type:   "contains",
+					},
+				},
+			},
+			wantPackages: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:           "npm",
+						Name:           "foo",
+						Version:        "1.0.0",
+						DependencyType: types.DependencyTypeDirect,
+						Depth:          1,
+						Path:           []string{"foo"},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:           "npm",
+						Name:           "bar",
+						Version:        "1.0.0",
+						DependencyType: types.DependencyTypeTransitive,
+						Depth:          2,
+						Path:           []string{"foo", "bar"},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:           "npm",
+						Name:           "baz",
+						Version:        "1.0.0",
+						DependencyType: types.DependencyTypeTransitive,
+						Depth:          3,
+						Path:           []string{"foo", "bar", "baz"},
+					},
+				},
+			},
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
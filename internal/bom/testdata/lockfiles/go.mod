@@ -0,0 +1,8 @@
+module example.com/foo
+
+go 1.20
+
+require (
+	github.com/foo/bar v1.2.3
+	golang.org/x/sync v0.3.0 // indirect
+)
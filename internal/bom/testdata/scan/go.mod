@@ -0,0 +1,5 @@
+module example.com/foo
+
+go 1.20
+
+require github.com/foo/bar v1.2.3
@@ -0,0 +1,181 @@
+package bom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
+	"gopkg.in/yaml.v3"
+)
+
+// GitHubActionsType is the package type of the actions and reusable workflows
+// used by GitHub Actions workflows
+const GitHubActionsType = "github-actions"
+
+// ErrNoWorkflows is returned when a directory doesn't contain any workflows
+var ErrNoWorkflows = errors.New("no workflows found")
+
+type workflow struct {
+	Jobs map[string]workflowJob `yaml:"jobs"`
+}
+
+type workflowJob struct {
+	Uses  string         `yaml:"uses"`
+	Steps []workflowStep `yaml:"steps"`
+}
+
+type workflowStep struct {
+	Uses string `yaml:"uses"`
+}
+
+// PackageRepositoriesFromWorkflows discovers the actions and reusable
+// workflows used by a GitHub Actions workflow file or, if the path is a
+// directory, by the workflows in .github/workflows. The directory can either be
+// the root of a repository or the .github/workflows directory itself. Other
+// YAML files, like docker-compose files, aren't parsed as workflows.
+func PackageRepositoriesFromWorkflows(path string) ([]*types.PackageRepositories, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return packageRepositoriesFromWorkflowFile(path)
+	}
+
+	dir := path
+	if !isWorkflowsDir(path) {
+		dir = filepath.Join(path, ".github", "workflows")
+		if _, err := os.Stat(dir); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("%s: %w", path, ErrNoWorkflows)
+			}
+			return nil, err
+		}
+	}
+
+	var files []string
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s: %w", path, ErrNoWorkflows)
+	}
+	sort.Strings(files)
+
+	var pkgRepos []*types.PackageRepositories
+	for _, file := range files {
+		workflowPkgRepos, err := packageRepositoriesFromWorkflowFile(file)
+		if err != nil {
+			return nil, err
+		}
+		pkgRepos = MergePackageRepositories(pkgRepos, workflowPkgRepos...)
+	}
+
+	return pkgRepos, nil
+}
+
+// isWorkflowsDir returns true if the path is a .github/workflows directory
+func isWorkflowsDir(path string) bool {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	return filepath.Base(path) == "workflows" && filepath.Base(filepath.Dir(path)) == ".github"
+}
+
+func packageRepositoriesFromWorkflowFile(path string) ([]*types.PackageRepositories, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pkgRepos, err := packageRepositoriesFromWorkflow(f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return pkgRepos, nil
+}
+
+func packageRepositoriesFromWorkflow(r io.Reader) ([]*types.PackageRepositories, error) {
+	wf := &workflow{}
+	if err := yaml.NewDecoder(r).Decode(wf); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	// Iterate over the jobs in a consistent order
+	var jobs []string
+	for name := range wf.Jobs {
+		jobs = append(jobs, name)
+	}
+	sort.Strings(jobs)
+
+	var pkgRepos []*types.PackageRepositories
+	for _, name := range jobs {
+		job := wf.Jobs[name]
+		uses := []string{job.Uses}
+		for _, step := range job.Steps {
+			uses = append(uses, step.Uses)
+		}
+		for _, u := range uses {
+			pkgRepo := packageRepositoriesFromUses(u)
+			if pkgRepo == nil {
+				continue
+			}
+			pkgRepos = appendPackageRepositories(pkgRepos, pkgRepo)
+		}
+	}
+
+	return pkgRepos, nil
+}
+
+// packageRepositoriesFromUses parses a reference to an action or reusable
+// workflow in the form <owner>/<repo>[/<path>]@<ref>. Docker images and
+// actions in the same repository aren't packages, so they're ignored.
+func packageRepositoriesFromUses(uses string) *types.PackageRepositories {
+	uses = strings.TrimSpace(uses)
+	if uses == "" || strings.HasPrefix(uses, "docker://") || strings.HasPrefix(uses, "./") {
+		return nil
+	}
+	name, ref, ok := strings.Cut(uses, "@")
+	if !ok || ref == "" {
+		return nil
+	}
+	parts := strings.Split(name, "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+
+	// Actions in a subdirectory of a repository have a subpath. Reusable
+	// workflows are files in .github/workflows, rather than a directory.
+	subpath := strings.Join(parts[2:], "/")
+	if strings.HasPrefix(subpath, ".github/workflows/") {
+		subpath = ""
+	}
+
+	return &types.PackageRepositories{
+		Package: types.Package{
+			Type:    GitHubActionsType,
+			Name:    name,
+			Version: ref,
+		},
+		Repositories: []types.Repository{
+			{
+				Name:    vcs_url.Canonical("github.com/" + parts[0] + "/" + parts[1]),
+				Subpath: subpath,
+			},
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package bom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestPackageRepositoriesFromWorkflows(t *testing.T) {
+	checkout := &types.PackageRepositories{
+		Package: types.Package{
+			Type:    GitHubActionsType,
+			Name:    "actions/checkout",
+			Version: "v4",
+		},
+		Repositories: []types.Repository{
+			{
+				Name: "github.com/actions/checkout",
+			},
+		},
+	}
+	codeql := &types.PackageRepositories{
+		Package: types.Package{
+			Type:    GitHubActionsType,
+			Name:    "github/codeql-action/init",
+			Version: "v2",
+		},
+		Repositories: []types.Repository{
+			{
+				Name:    "github.com/github/codeql-action",
+				Subpath: "init",
+			},
+		},
+	}
+	setupGo := &types.PackageRepositories{
+		Package: types.Package{
+			Type:    GitHubActionsType,
+			Name:    "actions/setup-go",
+			Version: "93397bea11091df50f3d7e59dc26a7711a8bcfbe",
+		},
+		Repositories: []types.Repository{
+			{
+				Name: "github.com/actions/setup-go",
+			},
+		},
+	}
+	ciPackages := []*types.PackageRepositories{
+		checkout,
+		codeql,
+		setupGo,
+	}
+	releasePackages := []*types.PackageRepositories{
+		{
+			Package: types.Package{
+				Type:    GitHubActionsType,
+				Name:    "foo/workflows/.github/workflows/build.yml",
+				Version: "main",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/workflows",
+				},
+			},
+		},
+		{
+			Package: types.Package{
+				Type:    GitHubActionsType,
+				Name:    "actions/checkout",
+				Version: "v3",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/actions/checkout",
+				},
+			},
+		},
+		{
+			Package: types.Package{
+				Type:    GitHubActionsType,
+				Name:    "Foo/Publish-Action",
+				Version: "v1",
+			},
+			Repositories: []types.Repository{
+				{
+					Name: "github.com/foo/publish-action",
+				},
+			},
+		},
+	}
+	testCases := map[string]struct {
+		path         string
+		wantPackages []*types.PackageRepositories
+		wantErr      error
+	}{
+		"should skip docker images and local actions in a workflow file": {
+			path:         "testdata/workflows/.github/workflows/ci.yml",
+			wantPackages: ciPackages,
+		},
+		"should discover reusable workflows": {
+			path:         "testdata/workflows/.github/workflows/release.yaml",
+			wantPackages: releasePackages,
+		},
+		"should find the workflows in the root of a repository": {
+			path:         "testdata/workflows",
+			wantPackages: append(ciPackages, releasePackages...),
+		},
+		"should find the workflows in a workflows directory": {
+			path:         "testdata/workflows/.github/workflows",
+			wantPackages: append(ciPackages, releasePackages...),
+		},
+		"directories without workflows return ErrNoWorkflows": {
+			path:    "testdata/lockfiles",
+			wantErr: ErrNoWorkflows,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			gotPackages, err := PackageRepositoriesFromWorkflows(tc.path)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantPackages, gotPackages); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+		})
+	}
+}
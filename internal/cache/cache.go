@@ -18,4 +18,12 @@ type Cache interface {
 
 	// PutResult inserts a score into the cache
 	PutResult(ctx context.Context, repository string, result *models.ScorecardResult) error
+
+	// GetCanonicalName retrieves the canonical name of a repository from
+	// the cache
+	GetCanonicalName(ctx context.Context, repository string) (string, error)
+
+	// PutCanonicalName inserts the canonical name of a repository into the
+	// cache
+	PutCanonicalName(ctx context.Context, repository, name string) error
 }
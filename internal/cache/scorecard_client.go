@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jetstack/tally/internal/scorecard"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
 	"github.com/ossf/scorecard-webapp/app/generated/models"
 )
 
@@ -45,3 +46,33 @@ func (c *ScorecardClient) GetResult(ctx context.Context, repository string) (*mo
 
 	return result, nil
 }
+
+// CanonicalName attempts to get the canonical name of the repository from the
+// cache. Failing that it will get the name from the wrapped client, if it
+// supports it, and cache it for next time.
+func (c *ScorecardClient) CanonicalName(ctx context.Context, repository string) (string, error) {
+	repository = vcs_url.Canonical(repository)
+	canonicalizer, ok := c.Client.(scorecard.Canonicalizer)
+	if !ok {
+		return repository, nil
+	}
+
+	name, err := c.ca.GetCanonicalName(ctx, repository)
+	if err == nil {
+		return name, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return "", fmt.Errorf("getting canonical name from cache: %w", err)
+	}
+
+	name, err = canonicalizer.CanonicalName(ctx, repository)
+	if err != nil {
+		return "", fmt.Errorf("getting canonical name from wrapped client: %w", err)
+	}
+
+	if err := c.ca.PutCanonicalName(ctx, repository, name); err != nil {
+		return "", fmt.Errorf("caching canonical name: %w", err)
+	}
+
+	return name, nil
+}
@@ -12,6 +12,7 @@ import (
 
 type mockCache struct {
 	repoToScorecardResult map[string]*models.ScorecardResult
+	repoToCanonicalName   map[string]string
 	putErr                error
 	getErr                error
 }
@@ -39,6 +40,35 @@ func (c *mockCache) PutResult(ctx context.Context, repository string, result *mo
 	return nil
 }
 
+func (c *mockCache) GetCanonicalName(ctx context.Context, repository string) (string, error) {
+	name, ok := c.repoToCanonicalName[repository]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return name, nil
+}
+
+func (c *mockCache) PutCanonicalName(ctx context.Context, repository, name string) error {
+	c.repoToCanonicalName[repository] = name
+
+	return nil
+}
+
+type mockCanonicalizer struct {
+	mockScorecardClient
+	repoToCanonicalName map[string]string
+}
+
+func (c *mockCanonicalizer) CanonicalName(ctx context.Context, repository string) (string, error) {
+	name, ok := c.repoToCanonicalName[repository]
+	if !ok {
+		return "", scorecard.ErrNotFound
+	}
+
+	return name, nil
+}
+
 type mockScorecardClient struct {
 	repoToScorecardResult map[string]*models.ScorecardResult
 	getErr                error
@@ -49,6 +79,10 @@ func (c *mockScorecardClient) Name() string {
 	return c.name
 }
 
+func (c *mockScorecardClient) Supports(repository string) bool {
+	return true
+}
+
 func (c *mockScorecardClient) GetResult(ctx context.Context, repository string) (*models.ScorecardResult, error) {
 	if c.getErr != nil {
 		return nil, c.getErr
@@ -225,3 +259,63 @@ func TestScorecardClientName(t *testing.T) {
 		t.Errorf("unexpected name returned by caching client; wanted %s but got %s", wantName, gotName)
 	}
 }
+
+func TestScorecardClientCanonicalName(t *testing.T) {
+	ca := &mockCache{
+		repoToCanonicalName: map[string]string{
+			"github.com/foo/cached": "github.com/bar/cached",
+		},
+	}
+	client := NewScorecardClient(ca, &mockCanonicalizer{
+		repoToCanonicalName: map[string]string{
+			"github.com/foo/bar": "github.com/bar/baz",
+		},
+	}).(scorecard.Canonicalizer)
+
+	testCases := map[string]struct {
+		repository string
+		wantName   string
+		wantErr    error
+	}{
+		"should return the name from the cache": {
+			repository: "github.com/foo/cached",
+			wantName:   "github.com/bar/cached",
+		},
+		"should return the name from the wrapped client": {
+			repository: "github.com/Foo/Bar",
+			wantName:   "github.com/bar/baz",
+		},
+		"should return errors from the wrapped client": {
+			repository: "github.com/foo/missing",
+			wantErr:    scorecard.ErrNotFound,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			gotName, err := client.CanonicalName(context.Background(), tc.repository)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotName != tc.wantName {
+				t.Errorf("unexpected name; wanted %q but got %q", tc.wantName, gotName)
+			}
+		})
+	}
+
+	// Names from the wrapped client should be cached
+	if ca.repoToCanonicalName["github.com/foo/bar"] != "github.com/bar/baz" {
+		t.Errorf("expected the canonical name to be cached")
+	}
+}
+
+func TestScorecardClientCanonicalNameUnsupported(t *testing.T) {
+	client := NewScorecardClient(&mockCache{}, &mockScorecardClient{}).(scorecard.Canonicalizer)
+
+	gotName, err := client.CanonicalName(context.Background(), " github.com/Foo/Bar")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if gotName != "github.com/foo/bar" {
+		t.Errorf("unexpected name; wanted github.com/foo/bar but got %s", gotName)
+	}
+}
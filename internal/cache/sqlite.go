@@ -22,6 +22,14 @@ CREATE TABLE IF NOT EXISTS results (
   result text NOT NULL,
   timestamp DATETIME NOT NULL
 );
+`
+
+	createCanonicalNamesTableStatement = `
+CREATE TABLE IF NOT EXISTS canonical_names (
+  repository text NOT NULL UNIQUE,
+  name text NOT NULL,
+  timestamp DATETIME NOT NULL
+);
 `
 
 	selectResultQuery = `
@@ -34,6 +42,18 @@ WHERE repository = ?;
 INSERT or REPLACE INTO results
 (repository, result, timestamp)
 VALUES (?, ?, ?)
+`
+
+	selectCanonicalNameQuery = `
+SELECT name, timestamp
+FROM canonical_names
+WHERE repository = ?;
+`
+
+	insertCanonicalNameStatement = `
+INSERT or REPLACE INTO canonical_names
+(repository, name, timestamp)
+VALUES (?, ?, ?)
 `
 )
 
@@ -69,6 +89,9 @@ func NewSqliteCache(dbDir string, opts ...Option) (Cache, error) {
 	if _, err := db.Exec(createTableStatement); err != nil {
 		return nil, fmt.Errorf("creating scores table in database: %w", err)
 	}
+	if _, err := db.Exec(createCanonicalNamesTableStatement); err != nil {
+		return nil, fmt.Errorf("creating canonical names table in database: %w", err)
+	}
 
 	return &sqliteCache{
 		db:      db,
@@ -140,3 +163,45 @@ func (c *sqliteCache) PutResult(ctx context.Context, repository string, result *
 	}
 	return nil
 }
+
+// GetCanonicalName will retrieve the canonical name of a repository from the
+// cache
+func (c *sqliteCache) GetCanonicalName(ctx context.Context, repository string) (string, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	var (
+		name      string
+		timestamp time.Time
+	)
+	err := c.db.QueryRowContext(ctx, selectCanonicalNameQuery, repository).Scan(&name, &timestamp)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("getting canonical name from database: %w", err)
+	}
+
+	if timestamp.Add(c.opts.Duration).Before(c.timeNow()) {
+		return "", ErrNotFound
+	}
+
+	return name, nil
+}
+
+// PutCanonicalName will put the canonical name of a repository into the cache
+func (c *sqliteCache) PutCanonicalName(ctx context.Context, repository, name string) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if _, err := c.db.ExecContext(
+		ctx,
+		insertCanonicalNameStatement,
+		repository,
+		name,
+		c.timeNow(),
+	); err != nil {
+		return fmt.Errorf("inserting canonical name: %w", err)
+	}
+	return nil
+}
@@ -117,3 +117,36 @@ func TestSqliteCacheGet_NotFound(t *testing.T) {
 		t.Errorf("unexpected error; wanted %q but got %q", ErrNotFound, err)
 	}
 }
+
+func TestSqliteCachePutGetCanonicalName(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cache, err := NewSqliteCache(tmpDir, WithDuration(1*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error creating cache: %s", err)
+	}
+
+	if _, err := cache.GetCanonicalName(context.Background(), "github.com/foo/bar"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error; wanted %q but got %q", ErrNotFound, err)
+	}
+
+	if err := cache.PutCanonicalName(context.Background(), "github.com/foo/bar", "github.com/bar/baz"); err != nil {
+		t.Fatalf("unexpected error putting canonical name in cache: %s", err)
+	}
+	name, err := cache.GetCanonicalName(context.Background(), "github.com/foo/bar")
+	if err != nil {
+		t.Fatalf("unexpected error retrieving canonical name from cache: %s", err)
+	}
+	if name != "github.com/bar/baz" {
+		t.Errorf("unexpected canonical name; wanted github.com/bar/baz but got %s", name)
+	}
+
+	// override the time.Now function so that GetCanonicalName believes
+	// it's 2 hours in the future
+	cache.(*sqliteCache).timeNow = func() time.Time {
+		return time.Now().Add(2 * time.Hour)
+	}
+	if _, err := cache.GetCanonicalName(context.Background(), "github.com/foo/bar"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error; wanted %q but got %q", ErrNotFound, err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/jetstack/tally/internal/types"
@@ -48,6 +50,14 @@ func WithAll(all bool) Option {
 	}
 }
 
+// WithPaths is a functional option that configures outputs to include the
+// dependency path to each package, where it is known
+func WithPaths(paths bool) Option {
+	return func(o *output) {
+		o.paths = paths
+	}
+}
+
 // Output writes output for tally
 type Output interface {
 	WriteReport(io.Writer, types.Report) error
@@ -76,6 +86,7 @@ func NewOutput(format Format, opts ...Option) (Output, error) {
 
 type output struct {
 	all    bool
+	paths  bool
 	writer func(io.Writer, types.Report) error
 }
 
@@ -104,9 +115,36 @@ func (o *output) WriteReport(w io.Writer, report types.Report) error {
 		return is > js
 	})
 
+	if !o.paths {
+		report = withoutPaths(report)
+	}
+
 	return o.writer(w, report)
 }
 
+func withoutPaths(report types.Report) types.Report {
+	results := make([]types.Result, len(report.Results))
+	for i, result := range report.Results {
+		pkgs := make([]types.Package, len(result.Packages))
+		for j, pkg := range result.Packages {
+			pkg.Path = nil
+			pkgs[j] = pkg
+		}
+		result.Packages = pkgs
+		results[i] = result
+	}
+	report.Results = results
+
+	excluded := make([]types.PackageRepositories, len(report.Excluded))
+	for i, pkgRepo := range report.Excluded {
+		pkgRepo.Path = nil
+		excluded[i] = pkgRepo
+	}
+	report.Excluded = excluded
+
+	return report
+}
+
 func (o *output) writeShort(w io.Writer, report types.Report) error {
 	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
 	defer tw.Flush()
@@ -123,7 +161,7 @@ func (o *output) writeShort(w io.Writer, report types.Report) error {
 		if result.Result != nil {
 			fmt.Fprintf(tw, "%s\t%.1f\n", result.Repository.Name, result.Result.Score)
 		} else if o.all {
-			fmt.Fprintf(tw, "%s\t%s\n", result.Repository.Name, " ")
+			fmt.Fprintf(tw, "%s\t%s\n", result.Repository.Name, noScore(result))
 		}
 		printed[result.Repository.Name] = struct{}{}
 	}
@@ -134,14 +172,36 @@ func (o *output) writeShort(w io.Writer, report types.Report) error {
 func (o *output) writeWide(w io.Writer, report types.Report) error {
 	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
 	defer tw.Flush()
-	fmt.Fprintf(tw, "TYPE\tPACKAGE\tREPOSITORY\tSCORE\n")
+	if o.paths {
+		fmt.Fprintf(tw, "TYPE\tPACKAGE\tVERSION\tDEPENDENCY\tDEPTH\tREPOSITORY\tSCORE\tPATH\n")
+	} else {
+		fmt.Fprintf(tw, "TYPE\tPACKAGE\tVERSION\tDEPENDENCY\tDEPTH\tREPOSITORY\tSCORE\n")
+	}
 
 	for _, result := range report.Results {
 		for _, pkg := range result.Packages {
+			version := " "
+			if pkg.Version != "" {
+				version = pkg.Version
+			}
+			depth := " "
+			if pkg.Depth > 0 {
+				depth = strconv.Itoa(pkg.Depth)
+			}
+			dependencyType := " "
+			if pkg.DependencyType != "" {
+				dependencyType = string(pkg.DependencyType)
+			}
+			score := noScore(result)
 			if result.Result != nil {
-				fmt.Fprintf(tw, "%s\t%s\t%s\t%.1f\n", pkg.Type, pkg.Name, result.Repository.Name, result.Result.Score)
-			} else if o.all {
-				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", pkg.Type, pkg.Name, result.Repository.Name, " ")
+				score = fmt.Sprintf("%.1f", result.Result.Score)
+			} else if !o.all {
+				continue
+			}
+			if o.paths {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", pkg.Type, pkg.Name, version, dependencyType, depth, result.Repository, score, strings.Join(pkg.Path, " > "))
+			} else {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", pkg.Type, pkg.Name, version, dependencyType, depth, result.Repository, score)
 			}
 		}
 	}
@@ -149,6 +209,15 @@ func (o *output) writeWide(w io.Writer, report types.Report) error {
 	return nil
 }
 
+// noScore is printed in place of the score for results that don't have one
+func noScore(result types.Result) string {
+	if result.Unsupported {
+		return "unsupported"
+	}
+
+	return " "
+}
+
 func (o *output) writeJSON(w io.Writer, report types.Report) error {
 	data, err := json.Marshal(report)
 	if err != nil {
@@ -0,0 +1,103 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the default timeout for HTTP requests to registries
+const DefaultTimeout = time.Second * 30
+
+const userAgent = "tally (https://github.com/jetstack/tally)"
+
+// Option is a functional option that configures a resolver
+type Option func(c *client)
+
+// WithTimeout is a functional option that configures the timeout duration for
+// HTTP requests
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithHTTPClient is a functional option that configures the client used for
+// HTTP requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *client) {
+		c.httpClient = httpClient
+	}
+}
+
+// client makes requests to a registry
+type client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newClient(rawURL, defaultURL string, opts ...Option) (*client, error) {
+	if rawURL == "" {
+		rawURL = defaultURL
+	}
+	if _, err := url.Parse(rawURL); err != nil {
+		return nil, fmt.Errorf("parsing url: %w", err)
+	}
+
+	c := &client{
+		baseURL: strings.TrimSuffix(rawURL, "/"),
+		httpClient: &http.Client{
+			Timeout: DefaultTimeout,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// get fetches the document at the path made up of the given elements, which
+// are escaped and joined onto the base url
+func (c *client) get(ctx context.Context, elem ...string) ([]byte, error) {
+	escaped := make([]string, len(elem))
+	for i, e := range elem {
+		escaped[i] = url.PathEscape(e)
+	}
+
+	return c.getURL(ctx, c.baseURL+"/"+strings.Join(escaped, "/"))
+}
+
+// getURL fetches the document at the given url
+func (c *client) getURL(ctx context.Context, uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", uri, errors.Join(err, ErrUnexpectedResponse))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %d: %w", uri, resp.StatusCode, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %d: %w", uri, resp.StatusCode, ErrUnexpectedResponse)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading body from %s: %w", uri, err)
+	}
+
+	return body, nil
+}
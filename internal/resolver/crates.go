@@ -0,0 +1,53 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+// DefaultCratesURL is the default base url for the crates.io API
+const DefaultCratesURL = "https://crates.io/api/v1"
+
+type cratesResolver struct {
+	*client
+}
+
+// NewCratesResolver returns a resolver that finds repositories from the
+// repository field of crates in crates.io
+func NewCratesResolver(rawURL string, opts ...Option) (Resolver, error) {
+	c, err := newClient(rawURL, DefaultCratesURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cratesResolver{c}, nil
+}
+
+// Type returns the purl type of rust crates
+func (r *cratesResolver) Type() string {
+	return "cargo"
+}
+
+type cratesCrate struct {
+	Crate struct {
+		Homepage   string `json:"homepage"`
+		Repository string `json:"repository"`
+	} `json:"crate"`
+}
+
+// Resolve finds the repository of a crate
+func (r *cratesResolver) Resolve(ctx context.Context, pkg types.Package) ([]types.Repository, error) {
+	body, err := r.get(ctx, "crates", pkg.Name)
+	if err != nil {
+		return nil, err
+	}
+	c := &cratesCrate{}
+	if err := json.Unmarshal(body, c); err != nil {
+		return nil, fmt.Errorf("unmarshaling crate: %w", err)
+	}
+
+	return repositoriesFromURLs(c.Crate.Repository, c.Crate.Homepage)
+}
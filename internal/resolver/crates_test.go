@@ -0,0 +1,33 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestCratesResolver(t *testing.T) {
+	testResolver(t, NewCratesResolver, map[string]resolverTestCase{
+		"should resolve the repository": {
+			docs: map[string]string{
+				"/crates/foo": `{"crate": {"homepage": "https://example.com", "repository": "https://github.com/foo/bar"}}`,
+			},
+			pkg: types.Package{
+				Type: "cargo",
+				Name: "foo",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should return ErrNotFound for missing crates": {
+			pkg: types.Package{
+				Type: "cargo",
+				Name: "foo",
+			},
+			wantErr: ErrNotFound,
+		},
+	})
+}
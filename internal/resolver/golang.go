@@ -0,0 +1,255 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jetstack/tally/internal/types"
+	"golang.org/x/mod/module"
+)
+
+type goResolver struct {
+	*client
+
+	// private, noProxy and insecure are comma separated lists of module
+	// path prefix globs, configured like the go command with GOPRIVATE,
+	// GONOPROXY and GOINSECURE
+	private  string
+	noProxy  string
+	insecure string
+
+	mux sync.Mutex
+	// cache maps import path prefixes to their repository url
+	cache map[string]string
+}
+
+// NewGoResolver returns a resolver that finds the repositories of Go modules,
+// including those with vanity import paths, using the ?go-get=1 discovery
+// protocol. If a GOPROXY url is provided then the origin information from the
+// proxy is checked first.
+//
+// Like the go command, modules that match GOPRIVATE aren't looked up at all,
+// modules that match GONOPROXY aren't looked up in the proxy and modules that
+// match GOINSECURE are discovered over http.
+func NewGoResolver(proxyURL string, opts ...Option) (Resolver, error) {
+	c, err := newClient(proxyURL, "", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	noProxy := os.Getenv("GONOPROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("GOPRIVATE")
+	}
+
+	return &goResolver{
+		client:   c,
+		private:  os.Getenv("GOPRIVATE"),
+		noProxy:  noProxy,
+		insecure: os.Getenv("GOINSECURE"),
+		cache:    map[string]string{},
+	}, nil
+}
+
+// Type returns the purl type of Go modules
+func (r *goResolver) Type() string {
+	return "golang"
+}
+
+// Resolve finds the repository of a Go module. A module that can't be
+// discovered, because its host can't be reached or returns an error, is left
+// unresolved and the error is returned for Resolve to warn about.
+func (r *goResolver) Resolve(ctx context.Context, pkg types.Package) ([]types.Repository, error) {
+	if module.MatchPrefixPatterns(r.private, pkg.Name) {
+		return nil, fmt.Errorf("%s matches GOPRIVATE: %w", pkg.Name, ErrNotFound)
+	}
+
+	if repoURL, ok := r.getCached(pkg.Name); ok {
+		return repositoriesFromURLs(repoURL)
+	}
+
+	// A proxy that doesn't have the module, or fails to respond, shouldn't
+	// stop discovery from the module's host
+	if r.baseURL != "" && !module.MatchPrefixPatterns(r.noProxy, pkg.Name) {
+		repoURL, err := r.resolveFromProxy(ctx, pkg)
+		if err == nil && repoURL != "" {
+			r.putCached(pkg.Name, repoURL)
+			return repositoriesFromURLs(repoURL)
+		}
+	}
+
+	prefix, repoURL, err := r.resolveFromGoGet(ctx, pkg.Name)
+	if err != nil {
+		return nil, err
+	}
+	r.putCached(prefix, repoURL)
+
+	return repositoriesFromURLs(repoURL)
+}
+
+func (r *goResolver) getCached(importPath string) (string, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for prefix, repoURL := range r.cache {
+		if importPath == prefix || strings.HasPrefix(importPath, prefix+"/") {
+			return repoURL, true
+		}
+	}
+
+	return "", false
+}
+
+func (r *goResolver) putCached(prefix, repoURL string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.cache[prefix] = repoURL
+}
+
+type goProxyInfo struct {
+	Origin struct {
+		VCS string `json:"VCS"`
+		URL string `json:"URL"`
+	} `json:"Origin"`
+}
+
+// resolveFromProxy returns the repository url from the origin information
+// that recent proxies include in the version info
+func (r *goResolver) resolveFromProxy(ctx context.Context, pkg types.Package) (string, error) {
+	escapedPath, err := module.EscapePath(pkg.Name)
+	if err != nil {
+		return "", fmt.Errorf("escaping module path: %w", errors.Join(ErrNotFound, err))
+	}
+	elem := strings.Split(escapedPath, "/")
+	if pkg.Version != "" {
+		escapedVersion, err := module.EscapeVersion(pkg.Version)
+		if err != nil {
+			return "", fmt.Errorf("escaping module version: %w", errors.Join(ErrNotFound, err))
+		}
+		elem = append(elem, "@v", escapedVersion+".info")
+	} else {
+		elem = append(elem, "@latest")
+	}
+
+	body, err := r.get(ctx, elem...)
+	if err != nil {
+		return "", err
+	}
+	info := &goProxyInfo{}
+	if err := json.Unmarshal(body, info); err != nil {
+		return "", fmt.Errorf("unmarshaling proxy info: %w", err)
+	}
+
+	return info.Origin.URL, nil
+}
+
+// resolveFromGoGet returns the import path prefix and repository url from the
+// go-import meta tag served at https://<import path>?go-get=1
+func (r *goResolver) resolveFromGoGet(ctx context.Context, importPath string) (string, string, error) {
+	scheme := "https"
+	if module.MatchPrefixPatterns(r.insecure, importPath) {
+		scheme = "http"
+	}
+	body, err := r.getURL(ctx, fmt.Sprintf("%s://%s?go-get=1", scheme, importPath))
+	if err != nil {
+		return "", "", fmt.Errorf("discovering repository for %s: %w", importPath, err)
+	}
+	imports, err := parseGoImports(bytes.NewReader(body))
+	if err != nil {
+		return "", "", fmt.Errorf("parsing go-import meta tags: %w", err)
+	}
+
+	// Use the most specific prefix that matches the import path
+	var prefix, repoURL string
+	for _, imp := range imports {
+		if imp.VCS == "mod" {
+			continue
+		}
+		if importPath != imp.Prefix && !strings.HasPrefix(importPath, imp.Prefix+"/") {
+			continue
+		}
+		if len(imp.Prefix) > len(prefix) {
+			prefix, repoURL = imp.Prefix, imp.RepoRoot
+		}
+	}
+	if prefix == "" {
+		return "", "", fmt.Errorf("no go-import meta tag for %s: %w", importPath, ErrNotFound)
+	}
+
+	return prefix, repoURL, nil
+}
+
+type goImport struct {
+	Prefix   string
+	VCS      string
+	RepoRoot string
+}
+
+// parseGoImports finds the go-import meta tags in an HTML document. Like the go
+// command, it parses the document leniently as XML and stops at the body.
+func parseGoImports(r io.Reader) ([]goImport, error) {
+	d := xml.NewDecoder(r)
+	d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		switch strings.ToLower(charset) {
+		case "utf-8", "ascii":
+			return input, nil
+		default:
+			return nil, fmt.Errorf("can't decode XML document using charset %q", charset)
+		}
+	}
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	var imports []goImport
+	for {
+		t, err := d.RawToken()
+		if err != nil {
+			if errors.Is(err, io.EOF) || len(imports) > 0 {
+				break
+			}
+			return nil, err
+		}
+		if e, ok := t.(xml.StartElement); ok && strings.EqualFold(e.Name.Local, "body") {
+			break
+		}
+		if e, ok := t.(xml.EndElement); ok && strings.EqualFold(e.Name.Local, "head") {
+			break
+		}
+		e, ok := t.(xml.StartElement)
+		if !ok || !strings.EqualFold(e.Name.Local, "meta") {
+			continue
+		}
+		if attrValue(e.Attr, "name") != "go-import" {
+			continue
+		}
+		if f := strings.Fields(attrValue(e.Attr, "content")); len(f) == 3 {
+			imports = append(imports, goImport{
+				Prefix:   f[0],
+				VCS:      f[1],
+				RepoRoot: f[2],
+			})
+		}
+	}
+
+	return imports, nil
+}
+
+func attrValue(attrs []xml.Attr, name string) string {
+	for _, a := range attrs {
+		if strings.EqualFold(a.Name.Local, name) {
+			return a.Value
+		}
+	}
+
+	return ""
+}
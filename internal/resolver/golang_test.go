@@ -0,0 +1,279 @@
+package resolver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+// newGoGetServer returns an HTTP client that sends every request to a test
+// server, regardless of the host, and the number of requests it has served.
+// Requests for error.example.com get a 500 response.
+func newGoGetServer(t *testing.T, docs map[string]string) (*http.Client, *int32) {
+	var requests int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Host == "error.example.com" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Query().Get("go-get") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		doc, ok := docs[r.Host+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, doc)
+	}))
+	t.Cleanup(ts.Close)
+
+	httpClient := ts.Client()
+	transport := httpClient.Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, ts.Listener.Addr().String())
+	}
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	httpClient.Transport = transport
+
+	return httpClient, &requests
+}
+
+func TestGoResolver(t *testing.T) {
+	testCases := map[string]struct {
+		env          map[string]string
+		proxyURL     string
+		docs         map[string]string
+		pkgs         []types.Package
+		wantRepos    [][]types.Repository
+		wantRequests int32
+		wantErr      error
+	}{
+		"should resolve vanity import paths": {
+			docs: map[string]string{
+				"k8s.io/api": `<html><head><meta name="go-import" content="k8s.io/api git https://github.com/kubernetes/api"></head></html>`,
+			},
+			pkgs: []types.Package{
+				{
+					Type: "golang",
+					Name: "k8s.io/api",
+				},
+			},
+			wantRepos: [][]types.Repository{
+				{
+					{
+						Name: "github.com/kubernetes/api",
+					},
+				},
+			},
+			wantRequests: 1,
+		},
+		"should use the most specific prefix and ignore mod entries": {
+			docs: map[string]string{
+				"example.com/foo/bar": `<!DOCTYPE html>
+<html>
+<head>
+<meta name="go-import" content="example.com/foo git https://github.com/example/foo">
+<meta name="go-import" content="example.com/foo/bar git https://github.com/example/bar">
+<meta name="go-import" content="example.com/foo/bar mod https://proxy.example.com">
+</head>
+<body>
+<meta name="go-import" content="example.com/foo/bar git https://github.com/example/body">
+</body>
+</html>`,
+			},
+			pkgs: []types.Package{
+				{
+					Type: "golang",
+					Name: "example.com/foo/bar",
+				},
+			},
+			wantRepos: [][]types.Repository{
+				{
+					{
+						Name: "github.com/example/bar",
+					},
+				},
+			},
+			wantRequests: 1,
+		},
+		"should cache results by prefix": {
+			docs: map[string]string{
+				"go.uber.org/zap": `<meta name="go-import" content="go.uber.org/zap git https://github.com/uber-go/zap">`,
+			},
+			pkgs: []types.Package{
+				{
+					Type: "golang",
+					Name: "go.uber.org/zap",
+				},
+				{
+					Type: "golang",
+					Name: "go.uber.org/zap/exp",
+				},
+			},
+			wantRepos: [][]types.Repository{
+				{
+					{
+						Name: "github.com/uber-go/zap",
+					},
+				},
+				{
+					{
+						Name: "github.com/uber-go/zap",
+					},
+				},
+			},
+			wantRequests: 1,
+		},
+		"should return ErrNotFound when there isn't a matching meta tag": {
+			docs: map[string]string{
+				"example.com/foo": `<meta name="go-import" content="example.com/bar git https://github.com/example/bar">`,
+			},
+			pkgs: []types.Package{
+				{
+					Type: "golang",
+					Name: "example.com/foo",
+				},
+			},
+			wantRepos:    [][]types.Repository{nil},
+			wantRequests: 1,
+			wantErr:      ErrNotFound,
+		},
+		"should return ErrUnexpectedResponse when the host returns an error": {
+			pkgs: []types.Package{
+				{
+					Type: "golang",
+					Name: "error.example.com/foo",
+				},
+			},
+			wantRepos:    [][]types.Repository{nil},
+			wantRequests: 1,
+			wantErr:      ErrUnexpectedResponse,
+		},
+		"should skip modules that match GOPRIVATE": {
+			env: map[string]string{
+				"GOPRIVATE": "corp.internal,*.corp.example.com",
+			},
+			pkgs: []types.Package{
+				{
+					Type: "golang",
+					Name: "corp.internal/foo",
+				},
+				{
+					Type: "golang",
+					Name: "git.corp.example.com/foo/bar",
+				},
+			},
+			wantRepos:    [][]types.Repository{nil, nil},
+			wantRequests: 0,
+			wantErr:      ErrNotFound,
+		},
+		"should fall back to go-get when the proxy returns an error": {
+			proxyURL: "https://proxy.example.com",
+			docs: map[string]string{
+				"k8s.io/api": `<meta name="go-import" content="k8s.io/api git https://github.com/kubernetes/api">`,
+			},
+			pkgs: []types.Package{
+				{
+					Type:    "golang",
+					Name:    "k8s.io/api",
+					Version: "v0.28.0",
+				},
+			},
+			wantRepos: [][]types.Repository{
+				{
+					{
+						Name: "github.com/kubernetes/api",
+					},
+				},
+			},
+			wantRequests: 2,
+		},
+		"should skip the proxy for modules that match GONOPROXY": {
+			env: map[string]string{
+				"GONOPROXY": "k8s.io",
+			},
+			proxyURL: "https://proxy.example.com",
+			docs: map[string]string{
+				"k8s.io/api": `<meta name="go-import" content="k8s.io/api git https://github.com/kubernetes/api">`,
+			},
+			pkgs: []types.Package{
+				{
+					Type:    "golang",
+					Name:    "k8s.io/api",
+					Version: "v0.28.0",
+				},
+			},
+			wantRepos: [][]types.Repository{
+				{
+					{
+						Name: "github.com/kubernetes/api",
+					},
+				},
+			},
+			wantRequests: 1,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			for _, k := range []string{"GOPRIVATE", "GONOPROXY", "GOINSECURE"} {
+				t.Setenv(k, tc.env[k])
+			}
+			httpClient, requests := newGoGetServer(t, tc.docs)
+			r, err := NewGoResolver(tc.proxyURL, WithHTTPClient(httpClient))
+			if err != nil {
+				t.Fatalf("unexpected error creating resolver: %s", err)
+			}
+			for i, pkg := range tc.pkgs {
+				gotRepos, err := r.Resolve(context.Background(), pkg)
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(tc.wantRepos[i], gotRepos); diff != "" {
+					t.Errorf("unexpected repositories:\n%s", diff)
+				}
+			}
+			if *requests != tc.wantRequests {
+				t.Errorf("unexpected number of requests; wanted %d but got %d", tc.wantRequests, *requests)
+			}
+		})
+	}
+}
+
+func TestGoResolverProxy(t *testing.T) {
+	ts := newRegistryServer(t, map[string]string{
+		"/example.com/%21foo/@v/v1.0.0.info": `{"Version": "v1.0.0", "Origin": {"VCS": "git", "URL": "https://github.com/example/foo"}}`,
+	})
+	r, err := NewGoResolver(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error creating resolver: %s", err)
+	}
+
+	gotRepos, err := r.Resolve(context.Background(), types.Package{
+		Type:    "golang",
+		Name:    "example.com/Foo",
+		Version: "v1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantRepos := []types.Repository{
+		{
+			Name: "github.com/example/foo",
+		},
+	}
+	if diff := cmp.Diff(wantRepos, gotRepos); diff != "" {
+		t.Errorf("unexpected repositories:\n%s", diff)
+	}
+}
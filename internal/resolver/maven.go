@@ -0,0 +1,89 @@
+package resolver
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+// DefaultMavenURL is the default base url for Maven Central
+const DefaultMavenURL = "https://repo1.maven.org/maven2"
+
+type mavenResolver struct {
+	*client
+}
+
+// NewMavenResolver returns a resolver that finds repositories from the scm
+// section of POMs in a Maven repository
+func NewMavenResolver(rawURL string, opts ...Option) (Resolver, error) {
+	c, err := newClient(rawURL, DefaultMavenURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mavenResolver{c}, nil
+}
+
+// Type returns the purl type of maven packages
+func (r *mavenResolver) Type() string {
+	return "maven"
+}
+
+type mavenMetadata struct {
+	Versioning struct {
+		Latest  string `xml:"latest"`
+		Release string `xml:"release"`
+	} `xml:"versioning"`
+}
+
+type mavenPOM struct {
+	URL string `xml:"url"`
+	SCM struct {
+		URL                 string `xml:"url"`
+		Connection          string `xml:"connection"`
+		DeveloperConnection string `xml:"developerConnection"`
+	} `xml:"scm"`
+}
+
+// Resolve finds the repository of a maven package. If the package doesn't
+// have a version then the POM of the latest release is used.
+func (r *mavenResolver) Resolve(ctx context.Context, pkg types.Package) ([]types.Repository, error) {
+	group, artifact, ok := strings.Cut(pkg.Name, "/")
+	if !ok {
+		return nil, fmt.Errorf("expected maven package name in the format <group>/<artifact> but got %s: %w", pkg.Name, ErrNotFound)
+	}
+	path := append(strings.Split(group, "."), artifact)
+
+	version := pkg.Version
+	if version == "" {
+		body, err := r.get(ctx, append(path, "maven-metadata.xml")...)
+		if err != nil {
+			return nil, err
+		}
+		m := &mavenMetadata{}
+		if err := xml.Unmarshal(body, m); err != nil {
+			return nil, fmt.Errorf("unmarshaling maven metadata: %w", err)
+		}
+		version = m.Versioning.Release
+		if version == "" {
+			version = m.Versioning.Latest
+		}
+		if version == "" {
+			return nil, fmt.Errorf("no versions found for %s: %w", pkg.Name, ErrNotFound)
+		}
+	}
+
+	body, err := r.get(ctx, append(path, version, fmt.Sprintf("%s-%s.pom", artifact, version))...)
+	if err != nil {
+		return nil, err
+	}
+	p := &mavenPOM{}
+	if err := xml.Unmarshal(body, p); err != nil {
+		return nil, fmt.Errorf("unmarshaling pom: %w", err)
+	}
+
+	return repositoriesFromURLs(p.SCM.URL, p.SCM.Connection, p.SCM.DeveloperConnection, p.URL)
+}
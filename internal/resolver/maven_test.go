@@ -0,0 +1,65 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestMavenResolver(t *testing.T) {
+	testResolver(t, NewMavenResolver, map[string]resolverTestCase{
+		"should resolve the scm url": {
+			docs: map[string]string{
+				"/org/foo/bar/1.0.0/bar-1.0.0.pom": `<project><url>https://example.com</url><scm><url>https://github.com/foo/bar</url></scm></project>`,
+			},
+			pkg: types.Package{
+				Type:    "maven",
+				Name:    "org.foo/bar",
+				Version: "1.0.0",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should resolve the scm connection": {
+			docs: map[string]string{
+				"/org/foo/bar/1.0.0/bar-1.0.0.pom": `<project><scm><connection>scm:git:git://github.com/foo/bar.git</connection></scm></project>`,
+			},
+			pkg: types.Package{
+				Type:    "maven",
+				Name:    "org.foo/bar",
+				Version: "1.0.0",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should use the latest release when there is no version": {
+			docs: map[string]string{
+				"/org/foo/bar/maven-metadata.xml":  `<metadata><versioning><latest>2.0.0-SNAPSHOT</latest><release>1.0.0</release></versioning></metadata>`,
+				"/org/foo/bar/1.0.0/bar-1.0.0.pom": `<project><url>https://github.com/foo/bar</url></project>`,
+			},
+			pkg: types.Package{
+				Type: "maven",
+				Name: "org.foo/bar",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should return ErrNotFound for missing packages": {
+			pkg: types.Package{
+				Type:    "maven",
+				Name:    "org.foo/bar",
+				Version: "1.0.0",
+			},
+			wantErr: ErrNotFound,
+		},
+	})
+}
@@ -0,0 +1,73 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+// DefaultNPMURL is the default base url for the npm registry
+const DefaultNPMURL = "https://registry.npmjs.org"
+
+// npmShorthandRegex matches the <org>/<repo> shorthand for GitHub repositories
+var npmShorthandRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+type npmResolver struct {
+	*client
+}
+
+// NewNPMResolver returns a resolver that finds repositories from the
+// repository field of packages in the npm registry
+func NewNPMResolver(rawURL string, opts ...Option) (Resolver, error) {
+	c, err := newClient(rawURL, DefaultNPMURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &npmResolver{c}, nil
+}
+
+// Type returns the purl type of npm packages
+func (r *npmResolver) Type() string {
+	return "npm"
+}
+
+type npmPackage struct {
+	Repository json.RawMessage `json:"repository"`
+	Homepage   string          `json:"homepage"`
+}
+
+// Resolve finds the repository of an npm package
+func (r *npmResolver) Resolve(ctx context.Context, pkg types.Package) ([]types.Repository, error) {
+	body, err := r.get(ctx, pkg.Name)
+	if err != nil {
+		return nil, err
+	}
+	p := &npmPackage{}
+	if err := json.Unmarshal(body, p); err != nil {
+		return nil, fmt.Errorf("unmarshaling npm package: %w", err)
+	}
+
+	// The repository is either a string or an object with a url
+	var repoURL string
+	if err := json.Unmarshal(p.Repository, &repoURL); err != nil {
+		repo := struct {
+			URL string `json:"url"`
+		}{}
+		if err := json.Unmarshal(p.Repository, &repo); err == nil {
+			repoURL = repo.URL
+		}
+	}
+	switch {
+	case strings.HasPrefix(repoURL, "github:"):
+		repoURL = "github.com/" + strings.TrimPrefix(repoURL, "github:")
+	case npmShorthandRegex.MatchString(repoURL):
+		repoURL = "github.com/" + repoURL
+	}
+
+	return repositoriesFromURLs(repoURL, p.Homepage)
+}
@@ -0,0 +1,76 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestNPMResolver(t *testing.T) {
+	testResolver(t, NewNPMResolver, map[string]resolverTestCase{
+		"should resolve repository objects": {
+			docs: map[string]string{
+				"/foo": `{"repository": {"type": "git", "url": "git+https://github.com/foo/bar.git"}}`,
+			},
+			pkg: types.Package{
+				Type: "npm",
+				Name: "foo",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should resolve shorthand repositories for scoped packages": {
+			docs: map[string]string{
+				"/@foo%2Fbar": `{"repository": "github:foo/bar"}`,
+			},
+			pkg: types.Package{
+				Type: "npm",
+				Name: "@foo/bar",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should respect the path of the base url": {
+			basePath: "/api/npm/",
+			docs: map[string]string{
+				"/api/npm/foo": `{"repository": "foo/bar"}`,
+			},
+			pkg: types.Package{
+				Type: "npm",
+				Name: "foo",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should fall back to the homepage": {
+			docs: map[string]string{
+				"/foo": `{"homepage": "https://github.com/foo/bar#readme"}`,
+			},
+			pkg: types.Package{
+				Type: "npm",
+				Name: "foo",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should return ErrNotFound for missing packages": {
+			pkg: types.Package{
+				Type: "npm",
+				Name: "foo",
+			},
+			wantErr: ErrNotFound,
+		},
+	})
+}
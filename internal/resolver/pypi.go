@@ -0,0 +1,90 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+// DefaultPyPIURL is the default base url for PyPI
+const DefaultPyPIURL = "https://pypi.org/pypi"
+
+// pypiSourceLabels are the project url labels that typically point to the
+// source repository
+var pypiSourceLabels = []string{"source", "repository", "code", "github"}
+
+type pypiResolver struct {
+	*client
+}
+
+// NewPyPIResolver returns a resolver that finds repositories from the project
+// urls of packages in PyPI
+func NewPyPIResolver(rawURL string, opts ...Option) (Resolver, error) {
+	c, err := newClient(rawURL, DefaultPyPIURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pypiResolver{c}, nil
+}
+
+// Type returns the purl type of python packages
+func (r *pypiResolver) Type() string {
+	return "pypi"
+}
+
+type pypiProject struct {
+	Info struct {
+		HomePage    string            `json:"home_page"`
+		ProjectURLs map[string]string `json:"project_urls"`
+	} `json:"info"`
+}
+
+// Resolve finds the repository of a python package
+func (r *pypiResolver) Resolve(ctx context.Context, pkg types.Package) ([]types.Repository, error) {
+	body, err := r.get(ctx, pkg.Name, "json")
+	if err != nil {
+		return nil, err
+	}
+	p := &pypiProject{}
+	if err := json.Unmarshal(body, p); err != nil {
+		return nil, fmt.Errorf("unmarshaling pypi project: %w", err)
+	}
+
+	// Prefer the urls that are labelled as the source code, then the home
+	// page and then any other url
+	labels := make([]string, 0, len(p.Info.ProjectURLs))
+	for label := range p.Info.ProjectURLs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var sourceURLs, otherURLs []string
+	for _, label := range labels {
+		u := p.Info.ProjectURLs[label]
+		if isPyPISourceLabel(label) {
+			sourceURLs = append(sourceURLs, u)
+			continue
+		}
+		otherURLs = append(otherURLs, u)
+	}
+	urls := append(sourceURLs, p.Info.HomePage)
+	urls = append(urls, otherURLs...)
+
+	return repositoriesFromURLs(urls...)
+}
+
+func isPyPISourceLabel(label string) bool {
+	label = strings.ToLower(label)
+	for _, l := range pypiSourceLabels {
+		if strings.Contains(label, l) {
+			return true
+		}
+	}
+
+	return false
+}
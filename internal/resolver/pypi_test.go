@@ -0,0 +1,50 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestPyPIResolver(t *testing.T) {
+	testResolver(t, NewPyPIResolver, map[string]resolverTestCase{
+		"should prefer source project urls": {
+			docs: map[string]string{
+				"/foo/json": `{"info": {"home_page": "https://github.com/foo/home", "project_urls": {"Documentation": "https://github.com/foo/docs", "Source Code": "https://github.com/foo/bar"}}}`,
+			},
+			pkg: types.Package{
+				Type: "pypi",
+				Name: "foo",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should fall back to the home page": {
+			docs: map[string]string{
+				"/foo/json": `{"info": {"home_page": "https://github.com/foo/bar", "project_urls": {"Documentation": "https://github.com/foo/docs"}}}`,
+			},
+			pkg: types.Package{
+				Type: "pypi",
+				Name: "foo",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should return ErrNotFound when there are no repository urls": {
+			docs: map[string]string{
+				"/foo/json": `{"info": {"home_page": "https://example.com"}}`,
+			},
+			pkg: types.Package{
+				Type: "pypi",
+				Name: "foo",
+			},
+			wantErr: ErrNotFound,
+		},
+	})
+}
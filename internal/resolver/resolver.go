@@ -0,0 +1,116 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	// ErrNotFound is returned by a resolver when it can't find a repository
+	// for a package
+	ErrNotFound = errors.New("repository not found")
+
+	// ErrUnexpectedResponse is returned when a resolver gets an unexpected
+	// response from a registry
+	ErrUnexpectedResponse = errors.New("unexpected response")
+)
+
+// Resolver finds the source repository of a package, typically by querying the
+// package registry for its metadata
+type Resolver interface {
+	// Type is the purl type of the packages that this resolver supports
+	Type() string
+
+	// Resolve returns the repositories for the package
+	Resolve(ctx context.Context, pkg types.Package) ([]types.Repository, error)
+}
+
+// Resolve finds repositories for the packages that don't already have any,
+// using the resolver for each package's type. Each package name is only
+// resolved once, regardless of how many versions of it there are. Packages
+// that can't be resolved, because of an error from the registry, are left
+// without a repository and a warning is written to w.
+func Resolve(ctx context.Context, w io.Writer, resolvers []Resolver, pkgRepos ...*types.PackageRepositories) error {
+	// If the writer is nil then just discard anything we write
+	if w == nil {
+		w = io.Discard
+	}
+
+	byType := map[string]Resolver{}
+	for _, r := range resolvers {
+		byType[r.Type()] = r
+	}
+
+	// Group the packages that need resolving by type and name
+	type key struct {
+		Type string
+		Name string
+	}
+	unresolved := map[key][]*types.PackageRepositories{}
+	for _, pkgRepo := range pkgRepos {
+		if len(pkgRepo.Repositories) > 0 {
+			continue
+		}
+		if _, ok := byType[pkgRepo.Type]; !ok {
+			continue
+		}
+		k := key{Type: pkgRepo.Type, Name: pkgRepo.Name}
+		unresolved[k] = append(unresolved[k], pkgRepo)
+	}
+
+	var g errgroup.Group
+	g.SetLimit(runtime.NumCPU())
+	mux := sync.Mutex{}
+	for _, pkgs := range unresolved {
+		pkgs := pkgs
+		g.Go(func() error {
+			repos, err := byType[pkgs[0].Type].Resolve(ctx, pkgs[0].Package)
+			if errors.Is(err, ErrNotFound) {
+				return nil
+			}
+
+			mux.Lock()
+			defer mux.Unlock()
+
+			// One failed lookup shouldn't fail the whole run, unless
+			// the run itself has been cancelled
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return ctxErr
+				}
+				fmt.Fprintf(w, "Warning: resolving repository for %s/%s: %s\n", pkgs[0].Type, pkgs[0].Name, err)
+				return nil
+			}
+			for _, pkg := range pkgs {
+				pkg.AddRepositories(repos...)
+			}
+
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// repositoriesFromURLs returns the repository for the first url that can be
+// parsed into one. The urls should be ordered by preference.
+func repositoriesFromURLs(urls ...string) ([]types.Repository, error) {
+	for _, u := range urls {
+		repo := vcs_url.ToRepository(u)
+		if repo == nil {
+			continue
+		}
+
+		return []types.Repository{*repo}, nil
+	}
+
+	return nil, ErrNotFound
+}
@@ -0,0 +1,271 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+type fakeResolver struct {
+	calls int32
+	repos map[string][]types.Repository
+	err   error
+}
+
+func (r *fakeResolver) Type() string {
+	return "npm"
+}
+
+func (r *fakeResolver) Resolve(ctx context.Context, pkg types.Package) ([]types.Repository, error) {
+	atomic.AddInt32(&r.calls, 1)
+	if r.err != nil {
+		return nil, r.err
+	}
+	repos, ok := r.repos[pkg.Name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return repos, nil
+}
+
+func TestResolve(t *testing.T) {
+	testCases := map[string]struct {
+		resolver     *fakeResolver
+		pkgRepos     []*types.PackageRepositories
+		wantPkgRepos []*types.PackageRepositories
+		cancel       bool
+		wantCalls    int32
+		wantWarning  bool
+		wantErr      error
+	}{
+		"should resolve packages without repositories": {
+			resolver: &fakeResolver{
+				repos: map[string][]types.Repository{
+					"foo": {
+						{
+							Name: "github.com/foo/foo",
+						},
+					},
+				},
+			},
+			pkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "foo",
+						Version: "1.0.0",
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "foo",
+						Version: "2.0.0",
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "bar",
+					},
+				},
+			},
+			wantPkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "foo",
+						Version: "1.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/foo",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type:    "npm",
+						Name:    "foo",
+						Version: "2.0.0",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/foo",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "bar",
+					},
+				},
+			},
+			wantCalls: 2,
+		},
+		"should skip packages that already have repositories or have no resolver": {
+			resolver: &fakeResolver{},
+			pkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "foo",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "pypi",
+						Name: "foo",
+					},
+				},
+			},
+			wantPkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "foo",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/bar",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "pypi",
+						Name: "foo",
+					},
+				},
+			},
+		},
+		"should warn about unexpected errors and carry on": {
+			resolver: &fakeResolver{
+				err: ErrUnexpectedResponse,
+			},
+			pkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "foo",
+					},
+				},
+			},
+			wantPkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "foo",
+					},
+				},
+			},
+			wantCalls:   1,
+			wantWarning: true,
+		},
+		"should return an error when the context is cancelled": {
+			resolver: &fakeResolver{
+				err: context.Canceled,
+			},
+			cancel: true,
+			pkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "foo",
+					},
+				},
+			},
+			wantPkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "foo",
+					},
+				},
+			},
+			wantCalls: 1,
+			wantErr:   context.Canceled,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+			var w bytes.Buffer
+			err := Resolve(ctx, &w, []Resolver{tc.resolver}, tc.pkgRepos...)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotWarning := w.Len() > 0; gotWarning != tc.wantWarning {
+				t.Errorf("unexpected warning: %q", w.String())
+			}
+			if diff := cmp.Diff(tc.wantPkgRepos, tc.pkgRepos); diff != "" {
+				t.Errorf("unexpected packages:\n%s", diff)
+			}
+			if tc.resolver.calls != tc.wantCalls {
+				t.Errorf("unexpected number of calls to resolver; wanted %d but got %d", tc.wantCalls, tc.resolver.calls)
+			}
+		})
+	}
+}
+
+// newRegistryServer returns a server that responds with the given documents
+// by path, and a 404 for any other path
+func newRegistryServer(t *testing.T, docs map[string]string) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc, ok := docs[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(doc))
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+// testResolver runs a resolver against a test server for each test case
+func testResolver(t *testing.T, newResolver func(string, ...Option) (Resolver, error), testCases map[string]resolverTestCase) {
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ts := newRegistryServer(t, tc.docs)
+			r, err := newResolver(ts.URL + tc.basePath)
+			if err != nil {
+				t.Fatalf("unexpected error creating resolver: %s", err)
+			}
+			gotRepos, err := r.Resolve(context.Background(), tc.pkg)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantRepos, gotRepos); diff != "" {
+				t.Errorf("unexpected repositories:\n%s", diff)
+			}
+		})
+	}
+}
+
+type resolverTestCase struct {
+	basePath  string
+	docs      map[string]string
+	pkg       types.Package
+	wantRepos []types.Repository
+	wantErr   error
+}
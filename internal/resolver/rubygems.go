@@ -0,0 +1,51 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+// DefaultRubyGemsURL is the default base url for the RubyGems API
+const DefaultRubyGemsURL = "https://rubygems.org/api/v1"
+
+type rubyGemsResolver struct {
+	*client
+}
+
+// NewRubyGemsResolver returns a resolver that finds repositories from the
+// source code uri of gems in RubyGems
+func NewRubyGemsResolver(rawURL string, opts ...Option) (Resolver, error) {
+	c, err := newClient(rawURL, DefaultRubyGemsURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rubyGemsResolver{c}, nil
+}
+
+// Type returns the purl type of ruby gems
+func (r *rubyGemsResolver) Type() string {
+	return "gem"
+}
+
+type rubyGem struct {
+	SourceCodeURI string `json:"source_code_uri"`
+	HomepageURI   string `json:"homepage_uri"`
+}
+
+// Resolve finds the repository of a gem
+func (r *rubyGemsResolver) Resolve(ctx context.Context, pkg types.Package) ([]types.Repository, error) {
+	body, err := r.get(ctx, "gems", pkg.Name+".json")
+	if err != nil {
+		return nil, err
+	}
+	g := &rubyGem{}
+	if err := json.Unmarshal(body, g); err != nil {
+		return nil, fmt.Errorf("unmarshaling gem: %w", err)
+	}
+
+	return repositoriesFromURLs(g.SourceCodeURI, g.HomepageURI)
+}
@@ -0,0 +1,33 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestRubyGemsResolver(t *testing.T) {
+	testResolver(t, NewRubyGemsResolver, map[string]resolverTestCase{
+		"should resolve the source code uri": {
+			docs: map[string]string{
+				"/gems/foo.json": `{"homepage_uri": "https://example.com", "source_code_uri": "https://github.com/foo/bar/tree/v1.0.0"}`,
+			},
+			pkg: types.Package{
+				Type: "gem",
+				Name: "foo",
+			},
+			wantRepos: []types.Repository{
+				{
+					Name: "github.com/foo/bar",
+				},
+			},
+		},
+		"should return ErrNotFound for missing gems": {
+			pkg: types.Package{
+				Type: "gem",
+				Name: "foo",
+			},
+			wantErr: ErrNotFound,
+		},
+	})
+}
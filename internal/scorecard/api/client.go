@@ -9,9 +9,11 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jetstack/tally/internal/scorecard"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
 	"github.com/ossf/scorecard-webapp/app/generated/models"
 )
 
@@ -31,6 +33,10 @@ type Client struct {
 	baseURL       *url.URL
 	httpClient    *http.Client
 	githubBaseURL string
+	gitlabBaseURL string
+
+	mux            sync.RWMutex
+	canonicalNames map[string]string
 }
 
 // NewClient returns a client that fetches scores from the scorecard API
@@ -47,6 +53,7 @@ func NewClient(rawURL string, opts ...Option) (scorecard.Client, error) {
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
+		canonicalNames: map[string]string{},
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -60,48 +67,127 @@ func (c *Client) Name() string {
 	return ClientName
 }
 
-// GetResult fetches a scorecard result from the public scorecard API
-func (c *Client) GetResult(ctx context.Context, repository string) (*models.ScorecardResult, error) {
-	parts := strings.Split(repository, "/")
-	if len(parts) != 3 {
-		return nil, fmt.Errorf("unexpected number of parts in %s; wanted 3 but got %d: %w", repository, len(parts), scorecard.ErrInvalidRepository)
+// Supports returns true for repositories on github.com and gitlab.com
+func (c *Client) Supports(repository string) bool {
+	switch strings.SplitN(repository, "/", 2)[0] {
+	case "github.com", "gitlab.com":
+		return true
+	default:
+		return false
 	}
+}
 
-	switch parts[0] {
+// GetResult fetches a scorecard result from the public scorecard API
+func (c *Client) GetResult(ctx context.Context, repository string) (*models.ScorecardResult, error) {
 	// Ensure the repository is public before checking for a score. We want to
 	// avoid exposing private repositories to the API, as some users may
 	// consider that sensitive information they may not want shipping off to
 	// the Scorecard project.
+	name, err := c.CanonicalName(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get result from the Scorecard API
+	result, err := c.getResult(name)
+	if err != nil {
+		return nil, fmt.Errorf("fetching result: %w", err)
+	}
+
+	return result, nil
+}
+
+// CanonicalName checks that the repository is public and returns its
+// canonical name, following the redirect from a renamed GitHub repository to
+// its new name. Names are cached for the lifetime of the client.
+func (c *Client) CanonicalName(ctx context.Context, repository string) (string, error) {
+	repository = vcs_url.Canonical(repository)
+
+	c.mux.RLock()
+	name, ok := c.canonicalNames[repository]
+	c.mux.RUnlock()
+	if ok {
+		return name, nil
+	}
+
+	name, err := c.canonicalName(ctx, repository)
+	if err != nil {
+		return "", err
+	}
+
+	c.mux.Lock()
+	c.canonicalNames[repository] = name
+	c.mux.Unlock()
+
+	return name, nil
+}
+
+func (c *Client) canonicalName(ctx context.Context, repository string) (string, error) {
+	parts := strings.Split(repository, "/")
+	switch parts[0] {
 	case "github.com":
+		if len(parts) != 3 {
+			return "", fmt.Errorf("unexpected number of parts in %s; wanted 3 but got %d: %w", repository, len(parts), scorecard.ErrInvalidRepository)
+		}
 		baseURL := c.githubBaseURL
 		if baseURL == "" {
 			baseURL = fmt.Sprintf("https://%s", parts[0])
 		}
-		resp, err := c.httpClient.Head(fmt.Sprintf("%s/%s/%s", baseURL, parts[1], parts[2]))
+		finalURL, err := c.checkPublic(ctx, fmt.Sprintf("%s/%s/%s", baseURL, parts[1], parts[2]))
 		if err != nil {
-			return nil, fmt.Errorf("error checking if repository is public: %w", errors.Join(scorecard.ErrUnexpectedResponse, err))
+			return "", fmt.Errorf("checking github repository: %w", err)
 		}
-		if resp.StatusCode == http.StatusNotFound {
-			return nil, fmt.Errorf("github repository not found: %w", scorecard.ErrNotFound)
+
+		// The final url is the current location of the repository
+		finalParts := strings.Split(strings.Trim(finalURL.Path, "/"), "/")
+		if len(finalParts) != 2 {
+			return repository, nil
+		}
+
+		return vcs_url.Canonical(strings.Join([]string{parts[0], finalParts[0], finalParts[1]}, "/")), nil
+	case "gitlab.com":
+		// Projects can be nested in any number of groups
+		if len(parts) < 3 {
+			return "", fmt.Errorf("unexpected number of parts in %s; wanted at least 3 but got %d: %w", repository, len(parts), scorecard.ErrInvalidRepository)
+		}
+		baseURL := c.gitlabBaseURL
+		if baseURL == "" {
+			baseURL = fmt.Sprintf("https://%s", parts[0])
 		}
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("non-200 response from github when checking repository: %w", scorecard.ErrUnexpectedResponse)
+		if _, err := c.checkPublic(ctx, fmt.Sprintf("%s/api/v4/projects/%s", baseURL, url.PathEscape(strings.Join(parts[1:], "/")))); err != nil {
+			return "", fmt.Errorf("checking gitlab project: %w", err)
 		}
+
+		return repository, nil
 	default:
-		return nil, fmt.Errorf("unsupported repository platform %s: %w", parts[0], scorecard.ErrInvalidRepository)
+		return "", fmt.Errorf("%s: %w", repository, scorecard.ErrUnsupportedPlatform)
 	}
+}
 
-	// Get result from the Scorecard API
-	result, err := c.getResult(parts[0], parts[1], parts[2])
+// checkPublic checks that the repository can be accessed without
+// authentication. It returns the url of the response, after any redirects.
+func (c *Client) checkPublic(ctx context.Context, uri string) (*url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, uri, nil)
 	if err != nil {
-		return nil, fmt.Errorf("fetching result: %w", err)
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if repository is public: %w", errors.Join(scorecard.ErrUnexpectedResponse, err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("repository not found: %w", scorecard.ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("non-200 response when checking repository: %w", scorecard.ErrUnexpectedResponse)
 	}
 
-	return result, nil
+	return resp.Request.URL, nil
 }
 
-func (c *Client) getResult(platform, org, repo string) (*models.ScorecardResult, error) {
-	uri, err := c.baseURL.Parse(fmt.Sprintf("/projects/%s/%s/%s", platform, org, repo))
+func (c *Client) getResult(repository string) (*models.ScorecardResult, error) {
+	uri, err := c.baseURL.Parse("/projects/" + repository)
 	if err != nil {
 		return nil, fmt.Errorf("parsing path: %w", err)
 	}
@@ -18,7 +18,7 @@ import (
 func TestClientGetScore(t *testing.T) {
 	type testCase struct {
 		scorecardHandler    func(w http.ResponseWriter, r *http.Request)
-		githubHandler       func(w http.ResponseWriter, r *http.Request)
+		platformHandler     func(w http.ResponseWriter, r *http.Request)
 		repository          string
 		wantErr             error
 		wantScorecardResult *models.ScorecardResult
@@ -64,7 +64,7 @@ func TestClientGetScore(t *testing.T) {
 					w.Header().Set("Content-type", "application/json")
 					json.NewEncoder(w).Encode(wantScorecardResult)
 				},
-				githubHandler: func(w http.ResponseWriter, r *http.Request) {
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusOK)
 				},
 				wantScorecardResult: wantScorecardResult,
@@ -76,7 +76,7 @@ func TestClientGetScore(t *testing.T) {
 				scorecardHandler: func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusNotFound)
 				},
-				githubHandler: func(w http.ResponseWriter, r *http.Request) {
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusOK)
 				},
 				wantErr: scorecard.ErrNotFound,
@@ -88,7 +88,7 @@ func TestClientGetScore(t *testing.T) {
 				scorecardHandler: func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusInternalServerError)
 				},
-				githubHandler: func(w http.ResponseWriter, r *http.Request) {
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusOK)
 				},
 				wantErr: scorecard.ErrUnexpectedResponse,
@@ -100,22 +100,88 @@ func TestClientGetScore(t *testing.T) {
 				scorecardHandler: func(w http.ResponseWriter, r *http.Request) {
 					t.Fatalf("unexpected call to scorecard API")
 				},
-				githubHandler: func(w http.ResponseWriter, r *http.Request) {
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
 					t.Fatalf("unexpected call to github API")
 				},
 				wantErr: scorecard.ErrInvalidRepository,
 			}
 		},
-		"should return scorecard.ErrInvalidRepository for a non-github repo": func(t *testing.T) *testCase {
+		"should follow redirects to renamed github repositories": func(t *testing.T) *testCase {
+			wantScorecardResult := &models.ScorecardResult{
+				Repo: &models.Repo{
+					Name: "github.com/baz/qux",
+				},
+				Score: 6.5,
+			}
+			return &testCase{
+				repository: "github.com/Foo/Bar",
+				scorecardHandler: func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/projects/github.com/baz/qux" {
+						t.Fatalf("unexpected path: %s", r.URL.Path)
+					}
+					w.Header().Set("Content-type", "application/json")
+					json.NewEncoder(w).Encode(wantScorecardResult)
+				},
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
+					switch r.URL.Path {
+					case "/foo/bar":
+						http.Redirect(w, r, "/Baz/Qux", http.StatusMovedPermanently)
+					case "/Baz/Qux":
+						w.WriteHeader(http.StatusOK)
+					default:
+						t.Fatalf("unexpected path: %s", r.URL.Path)
+					}
+				},
+				wantScorecardResult: wantScorecardResult,
+			}
+		},
+		"should check that gitlab projects are public": func(t *testing.T) *testCase {
+			wantScorecardResult := &models.ScorecardResult{
+				Repo: &models.Repo{
+					Name: "gitlab.com/foo/bar/baz",
+				},
+				Score: 6.5,
+			}
+			return &testCase{
+				repository: "gitlab.com/foo/bar/baz",
+				scorecardHandler: func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/projects/gitlab.com/foo/bar/baz" {
+						t.Fatalf("unexpected path: %s", r.URL.Path)
+					}
+					w.Header().Set("Content-type", "application/json")
+					json.NewEncoder(w).Encode(wantScorecardResult)
+				},
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.EscapedPath() != "/api/v4/projects/foo%2Fbar%2Fbaz" {
+						t.Fatalf("unexpected path: %s", r.URL.EscapedPath())
+					}
+					w.WriteHeader(http.StatusOK)
+				},
+				wantScorecardResult: wantScorecardResult,
+			}
+		},
+		"should return scorecard.ErrNotFound when GitLab returns a 404": func(t *testing.T) *testCase {
 			return &testCase{
 				repository: "gitlab.com/foo/bar",
 				scorecardHandler: func(w http.ResponseWriter, r *http.Request) {
 					t.Fatalf("unexpected call to scorecard API")
 				},
-				githubHandler: func(w http.ResponseWriter, r *http.Request) {
-					t.Fatalf("unexpected call to github API")
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
 				},
-				wantErr: scorecard.ErrInvalidRepository,
+				wantErr: scorecard.ErrNotFound,
+			}
+		},
+		"should return scorecard.ErrUnsupportedPlatform for a bitbucket repo": func(t *testing.T) *testCase {
+			return &testCase{
+				repository: "bitbucket.org/foo/bar",
+				scorecardHandler: func(w http.ResponseWriter, r *http.Request) {
+					t.Fatalf("unexpected call to scorecard API")
+				},
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
+					t.Fatalf("unexpected call to platform API")
+				},
+				wantErr: scorecard.ErrUnsupportedPlatform,
 			}
 		},
 		"should return scorecard.ErrNotFound when Github returns a 404": func(t *testing.T) *testCase {
@@ -124,7 +190,7 @@ func TestClientGetScore(t *testing.T) {
 				scorecardHandler: func(w http.ResponseWriter, r *http.Request) {
 					t.Fatalf("unexpected call to scorecard API")
 				},
-				githubHandler: func(w http.ResponseWriter, r *http.Request) {
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusNotFound)
 				},
 				wantErr: scorecard.ErrNotFound,
@@ -136,7 +202,7 @@ func TestClientGetScore(t *testing.T) {
 				scorecardHandler: func(w http.ResponseWriter, r *http.Request) {
 					t.Fatalf("unexpected call to scorecard API")
 				},
-				githubHandler: func(w http.ResponseWriter, r *http.Request) {
+				platformHandler: func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusInternalServerError)
 				},
 				wantErr: scorecard.ErrUnexpectedResponse,
@@ -151,12 +217,13 @@ func TestClientGetScore(t *testing.T) {
 			server := httptest.NewServer(mux)
 			mux.HandleFunc("/projects/", tc.scorecardHandler)
 
-			gMux := http.NewServeMux()
-			gServer := httptest.NewServer(gMux)
-			gMux.HandleFunc("/", tc.githubHandler)
+			pMux := http.NewServeMux()
+			pServer := httptest.NewServer(pMux)
+			pMux.HandleFunc("/", tc.platformHandler)
 
 			opt := func(c *Client) {
-				c.githubBaseURL = gServer.URL
+				c.githubBaseURL = pServer.URL
+				c.gitlabBaseURL = pServer.URL
 			}
 			c, err := NewClient(server.URL, opt)
 			if err != nil {
@@ -173,3 +240,60 @@ func TestClientGetScore(t *testing.T) {
 		})
 	}
 }
+
+func TestClientSupports(t *testing.T) {
+	c, err := NewClient("")
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+	testCases := map[string]bool{
+		"github.com/foo/bar":      true,
+		"gitlab.com/foo/bar/baz":  true,
+		"bitbucket.org/foo/bar":   false,
+		"git.example.com/foo/bar": false,
+	}
+	for repository, want := range testCases {
+		if got := c.Supports(repository); got != want {
+			t.Errorf("unexpected result for %s; wanted %t but got %t", repository, want, got)
+		}
+	}
+}
+
+func TestClientCanonicalName(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/foo/bar":
+			http.Redirect(w, r, "/baz/bar", http.StatusMovedPermanently)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	opt := func(c *Client) {
+		c.githubBaseURL = server.URL
+	}
+	client, err := NewClient("", opt)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+	c := client.(scorecard.Canonicalizer)
+
+	for _, repository := range []string{"github.com/foo/bar", " github.com/FOO/bar/"} {
+		name, err := c.CanonicalName(context.Background(), repository)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if name != "github.com/baz/bar" {
+			t.Errorf("unexpected name; wanted github.com/baz/bar but got %s", name)
+		}
+	}
+
+	// The redirect and the repository it redirects to should only be
+	// requested once
+	if calls != 2 {
+		t.Errorf("expected the canonical name to be cached; wanted 2 requests but got %d", calls)
+	}
+}
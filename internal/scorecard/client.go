@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
 	"github.com/ossf/scorecard-webapp/app/generated/models"
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks"
@@ -30,6 +32,10 @@ var (
 	// ErrInvalidRepository is returned when an invalid repository is
 	// provided as input
 	ErrInvalidRepository = errors.New("invalid repository")
+
+	// ErrUnsupportedPlatform is returned when a client can't retrieve
+	// results for repositories on the platform
+	ErrUnsupportedPlatform = errors.New("unsupported platform")
 )
 
 // Client fetches scorecard results for repositories
@@ -40,6 +46,17 @@ type Client interface {
 
 	// Name returns the name of this client
 	Name() string
+
+	// Supports returns true if the client can retrieve results for the
+	// repository's platform
+	Supports(repository string) bool
+}
+
+// Canonicalizer is implemented by clients that can resolve the canonical name
+// of a repository, i.e by following the redirect from a renamed repository
+type Canonicalizer interface {
+	// CanonicalName returns the canonical name of the repository
+	CanonicalName(ctx context.Context, repository string) (string, error)
 }
 
 // ScorecardClientName is the name of the scorecard client
@@ -62,8 +79,26 @@ func (c *ScorecardClient) Name() string {
 	return ScorecardClientName
 }
 
+// Supports returns true for GitHub repositories. Scorecard only supports
+// GitLab when the SCORECARD_EXPERIMENTAL environment variable is set.
+func (c *ScorecardClient) Supports(repository string) bool {
+	switch vcs_url.PlatformOf(repository) {
+	case vcs_url.PlatformGitHub:
+		return strings.HasPrefix(repository, "github.com/")
+	case vcs_url.PlatformGitLab:
+		_, experimental := os.LookupEnv("SCORECARD_EXPERIMENTAL")
+		return experimental
+	default:
+		return false
+	}
+}
+
 // GetResult generates a scorecard result with the scorecard client
 func (c *ScorecardClient) GetResult(ctx context.Context, repository string) (*models.ScorecardResult, error) {
+	if !c.Supports(repository) {
+		return nil, fmt.Errorf("%s: %w", repository, ErrUnsupportedPlatform)
+	}
+
 	// Scorecard requires a logger but we want to suppress its output
 	logger := logrus.New()
 	logger.Out = ioutil.Discard
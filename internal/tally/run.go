@@ -11,6 +11,8 @@ import (
 	"github.com/cheggaaa/pb/v3"
 	"github.com/jetstack/tally/internal/scorecard"
 	"github.com/jetstack/tally/internal/types"
+	vcs_url "github.com/jetstack/tally/internal/vcs-url"
+	"github.com/ossf/scorecard-webapp/app/generated/models"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -23,8 +25,17 @@ func Run(ctx context.Context, w io.Writer, clients []scorecard.Client, pkgRepos
 		w = io.Discard
 	}
 
-	// Map repositories to packages
-	repoPkgs := map[string][]types.Package{}
+	// Resolve the canonical name of each repository, so that different
+	// names for the same repository are merged into one result
+	names, err := canonicalNames(ctx, w, clients, pkgRepos...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repositories to packages. Packages in the subdirectories of a
+	// monorepo are kept in separate results, which share the score of the
+	// repository.
+	repoPkgs := map[types.Repository][]types.Package{}
 	for _, pkgRepo := range pkgRepos {
 		// We want to include packages without a repository in the
 		// results
@@ -37,58 +48,79 @@ func Run(ctx context.Context, w io.Writer, clients []scorecard.Client, pkgRepos
 			}
 		}
 		for _, repo := range repos {
-			repoPkgs[repo.Name] = append(repoPkgs[repo.Name], pkgRepo.Package)
+			repo.Name = names[repo.Name]
+			if containsPackage(repoPkgs[repo], pkgRepo.Package) {
+				continue
+			}
+			repoPkgs[repo] = append(repoPkgs[repo], pkgRepo.Package)
 		}
 	}
 
 	// Map into results
-	var results []types.Result
-	for repoName, pkgs := range repoPkgs {
+	var (
+		results   []types.Result
+		repoNames []string
+	)
+	for repo, pkgs := range repoPkgs {
 		results = append(results, types.Result{
-			Repository: types.Repository{
-				Name: repoName,
-			},
-			Packages: pkgs,
+			Repository: repo,
+			Packages:   pkgs,
 		})
+		if repo.Name != "" && !containsString(repoNames, repo.Name) {
+			repoNames = append(repoNames, repo.Name)
+		}
 	}
 
-	bar := pb.ProgressBarTemplate(pbTemplate).Start(len(results))
+	// Mark the repositories that none of the clients support, rather
+	// than dropping them
+	for i, result := range results {
+		if result.Repository.Name == "" || supported(clients, result.Repository.Name) {
+			continue
+		}
+		results[i].Unsupported = true
+	}
+
+	bar := pb.ProgressBarTemplate(pbTemplate).Start(len(repoNames))
 	bar.SetWriter(w)
 	bar.Set(pb.CleanOnFinish, true)
 	defer bar.Finish()
 
+	// Find a score for each repository
+	scores := map[string]*models.ScorecardResult{}
 	for _, client := range clients {
 		var g errgroup.Group
 		g.SetLimit(runtime.NumCPU())
 		mux := sync.RWMutex{}
-		for i, result := range results {
-			if result.Result != nil || result.Repository.Name == "" {
+		for _, repoName := range repoNames {
+			mux.RLock()
+			_, ok := scores[repoName]
+			mux.RUnlock()
+			if ok || !client.Supports(repoName) {
 				continue
 			}
-			i, result := i, result
+			repoName := repoName
 			g.Go(func() error {
 				mux.Lock()
 				// Tweak the message displayed in the progress bar
 				// depending on the type of client
 				switch client.Name() {
 				case scorecard.ScorecardClientName:
-					bar.Set("message", fmt.Sprintf("Generating score for %q", result.Repository.Name))
+					bar.Set("message", fmt.Sprintf("Generating score for %q", repoName))
 				default:
-					bar.Set("message", fmt.Sprintf("Finding score for %q", result.Repository.Name))
+					bar.Set("message", fmt.Sprintf("Finding score for %q", repoName))
 				}
 				mux.Unlock()
 
-				scorecardResult, err := client.GetResult(ctx, result.Repository.Name)
+				scorecardResult, err := client.GetResult(ctx, repoName)
 				if err != nil && !errors.Is(err, scorecard.ErrNotFound) {
-					return fmt.Errorf("getting score for %s: %w", result.Repository.Name, err)
+					return fmt.Errorf("getting score for %s: %w", repoName, err)
 				}
 				if scorecardResult == nil {
 					return nil
 				}
 
-				results[i].Result = scorecardResult
-
 				mux.Lock()
+				scores[repoName] = scorecardResult
 				bar.Increment()
 				mux.Unlock()
 
@@ -99,6 +131,9 @@ func Run(ctx context.Context, w io.Writer, clients []scorecard.Client, pkgRepos
 			return nil, err
 		}
 	}
+	for i, result := range results {
+		results[i].Result = scores[result.Repository.Name]
+	}
 
 	bar.Set("message", "DONE")
 
@@ -106,3 +141,100 @@ func Run(ctx context.Context, w io.Writer, clients []scorecard.Client, pkgRepos
 		Results: results,
 	}, nil
 }
+
+func supported(clients []scorecard.Client, repository string) bool {
+	for _, client := range clients {
+		if client.Supports(repository) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canonicalNames maps the name of each repository to its canonical name, using
+// the first client that can resolve names for the repository's platform. When
+// the name can't be resolved, a warning is written to w and the name is used
+// as it is.
+func canonicalNames(ctx context.Context, w io.Writer, clients []scorecard.Client, pkgRepos ...*types.PackageRepositories) (map[string]string, error) {
+	names := map[string]string{
+		"": "",
+	}
+	for _, pkgRepo := range pkgRepos {
+		for _, repo := range pkgRepo.Repositories {
+			names[repo.Name] = vcs_url.Canonical(repo.Name)
+		}
+	}
+
+	// Take a copy of the names, so they can be updated concurrently
+	repoNames := make(map[string]string, len(names))
+	for repo, name := range names {
+		repoNames[repo] = name
+	}
+
+	var g errgroup.Group
+	g.SetLimit(runtime.NumCPU())
+	mux := sync.Mutex{}
+	for repo, name := range repoNames {
+		if name == "" {
+			continue
+		}
+		repo, name := repo, name
+		g.Go(func() error {
+			for _, client := range clients {
+				canonicalizer, ok := client.(scorecard.Canonicalizer)
+				if !ok || !client.Supports(name) {
+					continue
+				}
+				canonicalName, err := canonicalizer.CanonicalName(ctx, name)
+				if errors.Is(err, scorecard.ErrNotFound) {
+					return nil
+				}
+
+				mux.Lock()
+				defer mux.Unlock()
+
+				// Errors like rate limiting shouldn't stop the
+				// repository from being scored under the name it
+				// already has
+				if err != nil {
+					if ctxErr := ctx.Err(); ctxErr != nil {
+						return ctxErr
+					}
+					fmt.Fprintf(w, "Warning: getting canonical name for %s: %s\n", name, err)
+					return nil
+				}
+				names[repo] = canonicalName
+
+				return nil
+			}
+
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func containsPackage(pkgs []types.Package, pkg types.Package) bool {
+	for _, p := range pkgs {
+		if p.Equals(pkg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
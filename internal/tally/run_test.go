@@ -0,0 +1,203 @@
+package tally
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/scorecard"
+	"github.com/jetstack/tally/internal/types"
+	"github.com/ossf/scorecard-webapp/app/generated/models"
+)
+
+type mockClient struct {
+	repoToScorecardResult map[string]*models.ScorecardResult
+	repoToCanonicalName   map[string]string
+	canonicalNameErr      error
+}
+
+func (c *mockClient) GetResult(ctx context.Context, repository string) (*models.ScorecardResult, error) {
+	result, ok := c.repoToScorecardResult[repository]
+	if !ok {
+		return nil, scorecard.ErrNotFound
+	}
+
+	return result, nil
+}
+
+func (c *mockClient) Name() string {
+	return "mock"
+}
+
+func (c *mockClient) Supports(repository string) bool {
+	return strings.HasPrefix(repository, "github.com/")
+}
+
+func (c *mockClient) CanonicalName(ctx context.Context, repository string) (string, error) {
+	if c.canonicalNameErr != nil {
+		return "", c.canonicalNameErr
+	}
+	name, ok := c.repoToCanonicalName[repository]
+	if !ok {
+		return "", scorecard.ErrNotFound
+	}
+
+	return name, nil
+}
+
+func TestRun(t *testing.T) {
+	fooResult := &models.ScorecardResult{
+		Repo: &models.Repo{
+			Name: "github.com/foo/foo",
+		},
+		Score: 7.5,
+	}
+	testCases := map[string]struct {
+		client      *mockClient
+		pkgRepos    []*types.PackageRepositories
+		wantResults []types.Result
+		wantWarning bool
+	}{
+		"should merge repositories that resolve to the same canonical name": {
+			client: &mockClient{
+				repoToScorecardResult: map[string]*models.ScorecardResult{
+					"github.com/foo/foo": fooResult,
+				},
+				repoToCanonicalName: map[string]string{
+					"github.com/foo/old-foo": "github.com/foo/foo",
+				},
+			},
+			pkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "foo",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/old-foo",
+						},
+					},
+				},
+				{
+					Package: types.Package{
+						Type: "golang",
+						Name: "github.com/Foo/Foo",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/Foo/Foo",
+						},
+					},
+				},
+			},
+			wantResults: []types.Result{
+				{
+					Repository: types.Repository{
+						Name: "github.com/foo/foo",
+					},
+					Packages: []types.Package{
+						{
+							Type: "npm",
+							Name: "foo",
+						},
+						{
+							Type: "golang",
+							Name: "github.com/Foo/Foo",
+						},
+					},
+					Result: fooResult,
+				},
+			},
+		},
+		"should warn and use the repository name when the canonical name can't be resolved": {
+			client: &mockClient{
+				repoToScorecardResult: map[string]*models.ScorecardResult{
+					"github.com/foo/foo": fooResult,
+				},
+				canonicalNameErr: scorecard.ErrUnexpectedResponse,
+			},
+			pkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "foo",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "github.com/foo/foo",
+						},
+					},
+				},
+			},
+			wantResults: []types.Result{
+				{
+					Repository: types.Repository{
+						Name: "github.com/foo/foo",
+					},
+					Packages: []types.Package{
+						{
+							Type: "npm",
+							Name: "foo",
+						},
+					},
+					Result: fooResult,
+				},
+			},
+			wantWarning: true,
+		},
+		"should mark repositories on unsupported platforms": {
+			client: &mockClient{},
+			pkgRepos: []*types.PackageRepositories{
+				{
+					Package: types.Package{
+						Type: "npm",
+						Name: "bar",
+					},
+					Repositories: []types.Repository{
+						{
+							Name: "bitbucket.org/foo/bar",
+						},
+					},
+				},
+			},
+			wantResults: []types.Result{
+				{
+					Repository: types.Repository{
+						Name: "bitbucket.org/foo/bar",
+					},
+					Packages: []types.Package{
+						{
+							Type: "npm",
+							Name: "bar",
+						},
+					},
+					Unsupported: true,
+				},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var w bytes.Buffer
+			report, err := Run(context.Background(), &w, []scorecard.Client{tc.client}, tc.pkgRepos...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// Results are returned in no particular order
+			sort.Slice(report.Results, func(i, j int) bool {
+				return report.Results[i].Repository.String() < report.Results[j].Repository.String()
+			})
+			if diff := cmp.Diff(tc.wantResults, report.Results); diff != "" {
+				t.Errorf("unexpected results:\n%s", diff)
+			}
+			if gotWarning := strings.Contains(w.String(), "Warning:"); gotWarning != tc.wantWarning {
+				t.Errorf("unexpected warning: %q", w.String())
+			}
+		})
+	}
+}
@@ -1,12 +1,83 @@
 package types
 
+// DependencyType describes how a package is depended upon
+type DependencyType string
+
+const (
+	// DependencyTypeDirect is a package that is depended upon directly
+	DependencyTypeDirect DependencyType = "direct"
+
+	// DependencyTypeTransitive is a package that is depended upon by
+	// another dependency
+	DependencyTypeTransitive DependencyType = "transitive"
+)
+
 // Package is a package
 type Package struct {
-	Type string `json:"type"`
-	Name string `json:"name"`
+	Type       string            `json:"type"`
+	Name       string            `json:"name"`
+	Version    string            `json:"version,omitempty"`
+	Qualifiers map[string]string `json:"qualifiers,omitempty"`
+
+	// Scope is the CycloneDX scope of the component (required, optional or
+	// excluded), where it is known
+	Scope string `json:"scope,omitempty"`
+
+	// DependencyType, Depth and Path are only set when the package's
+	// position in the dependency graph is known. Direct dependencies have a
+	// depth of 1. Path is the shortest chain of packages from the root of
+	// the graph to this package.
+	DependencyType DependencyType `json:"dependencyType,omitempty"`
+	Depth          int            `json:"depth,omitempty"`
+	Path           []string       `json:"path,omitempty"`
+
+	// Sources are the inputs (i.e SBOMs) that the package was found in
+	Sources []string `json:"sources,omitempty"`
 }
 
-// Equals compares one package to another
+// Equals compares one package to another. Different versions of a package are
+// considered to be different packages. Qualifiers are not compared.
 func (pkg *Package) Equals(p Package) bool {
-	return pkg.Type == p.Type && pkg.Name == p.Name
+	return pkg.Type == p.Type && pkg.Name == p.Name && pkg.Version == p.Version
+}
+
+// SetDependency records the depth of the package in the dependency graph and
+// the path to it. If the package has already been found at a shallower depth
+// then that is retained.
+func (pkg *Package) SetDependency(depth int, path []string) {
+	if depth < 1 {
+		return
+	}
+	if pkg.Depth != 0 && pkg.Depth <= depth {
+		return
+	}
+
+	pkg.Depth = depth
+	pkg.Path = path
+	pkg.DependencyType = DependencyTypeTransitive
+	if depth == 1 {
+		pkg.DependencyType = DependencyTypeDirect
+	}
+}
+
+// AddSources records the inputs that the package was found in. It will ignore
+// any sources that are already recorded.
+func (pkg *Package) AddSources(sources ...string) {
+	for _, source := range sources {
+		if containsString(pkg.Sources, source) {
+			continue
+		}
+
+		pkg.Sources = append(pkg.Sources, source)
+	}
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
 }
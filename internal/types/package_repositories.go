@@ -20,7 +20,7 @@ func (pkg *PackageRepositories) AddRepositories(repos ...Repository) {
 
 func containsRepo(repos []Repository, repo Repository) bool {
 	for _, r := range repos {
-		if r.Name == repo.Name {
+		if r == repo {
 			return true
 		}
 	}
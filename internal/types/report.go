@@ -3,4 +3,7 @@ package types
 // Report details tally's findings
 type Report struct {
 	Results []Result `json:"results"`
+
+	// Excluded are the packages that were ignored and not scored
+	Excluded []PackageRepositories `json:"excluded,omitempty"`
 }
@@ -3,4 +3,19 @@ package types
 // Repository is a source code repository
 type Repository struct {
 	Name string `json:"name"`
+
+	// Subpath is the directory within the repository that contains the
+	// package, for packages in a monorepo. Scores are for the whole
+	// repository.
+	Subpath string `json:"subpath,omitempty"`
+}
+
+// String returns the name of the repository, followed by the subpath when
+// there is one
+func (r Repository) String() string {
+	if r.Subpath == "" {
+		return r.Name
+	}
+
+	return r.Name + "#" + r.Subpath
 }
@@ -7,4 +7,8 @@ type Result struct {
 	Repository Repository              `json:"repository,omitempty"`
 	Packages   []Package               `json:"packages,omitempty"`
 	Result     *models.ScorecardResult `json:"result,omitempty"`
+
+	// Unsupported is true when none of the scorecard clients support the
+	// platform that hosts the repository
+	Unsupported bool `json:"unsupported,omitempty"`
 }
@@ -0,0 +1,211 @@
+package vcs_url
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/jetstack/tally/internal/types"
+)
+
+// Platform is a source code hosting platform
+type Platform string
+
+const (
+	// PlatformGitHub is GitHub, where repositories are identified by
+	// <host>/<org>/<repo>
+	PlatformGitHub Platform = "github"
+
+	// PlatformGitLab is GitLab, where repositories are identified by
+	// <host>/<group>/[<subgroup>/...]<repo>
+	PlatformGitLab Platform = "gitlab"
+
+	// PlatformBitbucket is Bitbucket, where repositories are identified by
+	// <host>/<workspace>/<repo>
+	PlatformBitbucket Platform = "bitbucket"
+)
+
+// Platforms are the supported platforms
+var Platforms = []Platform{
+	PlatformGitHub,
+	PlatformGitLab,
+	PlatformBitbucket,
+}
+
+type host struct {
+	name     string
+	platform Platform
+	regex    *regexp.Regexp
+}
+
+var (
+	hostsMux sync.RWMutex
+	hosts    = map[string]*host{}
+)
+
+func init() {
+	for name, platform := range map[string]Platform{
+		"github.com":    PlatformGitHub,
+		"gitlab.com":    PlatformGitLab,
+		"bitbucket.org": PlatformBitbucket,
+	} {
+		if err := AddHost(name, platform); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// AddHost configures a host, like a self-hosted GitLab instance, that serves
+// repositories for the given platform
+func AddHost(name string, platform Platform) error {
+	if !isPlatform(platform) {
+		return fmt.Errorf("unsupported platform %q, options=%s", platform, Platforms)
+	}
+	name = strings.ToLower(strings.TrimSuffix(name, "/"))
+	if name == "" {
+		return fmt.Errorf("host must not be empty")
+	}
+
+	hostsMux.Lock()
+	defer hostsMux.Unlock()
+
+	hosts[name] = &host{
+		name:     name,
+		platform: platform,
+		regex:    regexp.MustCompile(`(?:^|://|@)(?:www\.)?(?i:` + regexp.QuoteMeta(name) + `)[/:]([^?#,\s"'<>]*)(?:\?[^#,\s]*)?(?:#([^,\s]*))?`),
+	}
+
+	return nil
+}
+
+// PlatformOf returns the platform that hosts the repository. It returns an
+// empty string if the host isn't known.
+func PlatformOf(repository string) Platform {
+	hostsMux.RLock()
+	defer hostsMux.RUnlock()
+
+	h, ok := hosts[strings.ToLower(strings.SplitN(repository, "/", 2)[0])]
+	if !ok {
+		return ""
+	}
+
+	return h.platform
+}
+
+// ToRepository parses a url for a repository on one of the known hosts from a
+// number of different formats into our expected repository format:
+// <host>/<path>. The subpath of a package in a monorepo is taken from the
+// fragment (i.e #packages/foo) or a tree url (i.e /tree/main/packages/foo).
+func ToRepository(u string) *types.Repository {
+	hostsMux.RLock()
+	defer hostsMux.RUnlock()
+
+	// Use the host that appears first in the url
+	var (
+		match    *host
+		matchLoc []int
+	)
+	for _, h := range hosts {
+		loc := h.regex.FindStringSubmatchIndex(u)
+		if loc == nil {
+			continue
+		}
+		if matchLoc != nil && loc[0] >= matchLoc[0] {
+			continue
+		}
+		match, matchLoc = h, loc
+	}
+	if match == nil {
+		return nil
+	}
+
+	path, subpath := repositoryPath(match.platform, strings.Split(u[matchLoc[2]:matchLoc[3]], "/"))
+	if path == "" {
+		return nil
+	}
+
+	// Fragments are also used for refs (i.e #v1.0.0) and pip options (i.e
+	// #egg=foo), so they're only considered to be a subpath when they
+	// contain a directory
+	if subpath == "" && matchLoc[4] != -1 {
+		fragment := u[matchLoc[4]:matchLoc[5]]
+		if strings.Contains(fragment, "/") && !strings.ContainsAny(fragment, "=&:") {
+			subpath = fragment
+		}
+	}
+
+	return &types.Repository{
+		Name:    Canonical(match.name + "/" + path),
+		Subpath: strings.Trim(subpath, "/"),
+	}
+}
+
+// Canonical returns the canonical form of a repository name. Hosts and paths
+// are case insensitive, so names are lowercased.
+func Canonical(name string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(name), "/"))
+}
+
+// repositoryPath returns the part of the path that identifies the repository
+// on the platform and the subpath within it, if the path points at a
+// directory in the repository
+func repositoryPath(platform Platform, segments []string) (string, string) {
+	var (
+		parts []string
+		rest  []string
+		tree  = "tree"
+	)
+	switch platform {
+	case PlatformGitLab:
+		// Projects can be nested in any number of groups. Anything
+		// after /-/ is a path within the project.
+		for i, segment := range segments {
+			if segment == "" || segment == "-" {
+				rest = segments[i:]
+				if segment == "-" {
+					rest = segments[i+1:]
+				}
+				break
+			}
+			parts = append(parts, segment)
+		}
+	case PlatformBitbucket:
+		tree = "src"
+		fallthrough
+	default:
+		if len(segments) >= 2 {
+			parts, rest = segments[:2], segments[2:]
+		}
+	}
+	if len(parts) < 2 {
+		return "", ""
+	}
+	// Requirement specifiers, like those used by pip, may pin a ref with
+	// @<ref> after the repository
+	last, _, _ := strings.Cut(parts[len(parts)-1], "@")
+	parts[len(parts)-1] = strings.TrimSuffix(last, ".git")
+	for _, part := range parts {
+		if part == "" {
+			return "", ""
+		}
+	}
+
+	// Directories are found at /<tree>/<ref>/<subpath>
+	var subpath string
+	if len(rest) >= 3 && rest[0] == tree {
+		subpath = strings.Join(rest[2:], "/")
+	}
+
+	return strings.Join(parts, "/"), subpath
+}
+
+func isPlatform(platform Platform) bool {
+	for _, p := range Platforms {
+		if p == platform {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,316 @@
+package vcs_url
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jetstack/tally/internal/types"
+)
+
+func TestToRepository(t *testing.T) {
+	testCases := []struct {
+		url      string
+		wantRepo *types.Repository
+	}{
+		{
+			url: "github.com/foo/bar",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar/tree/main/baz",
+			wantRepo: &types.Repository{
+				Name:    "github.com/foo/bar",
+				Subpath: "baz",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar#baz",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar/",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar.git/",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar.git?ref=baz",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar.git?ref=baz",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar?ref=something",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/bar#something",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git://github.com/foo/bar",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git://github.com/foo/bar",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git://github.com/foo/bar/",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git://github.com/foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git://github.com/foo/bar.git/",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git://github.com/foo/bar.git?ref=baz",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git://github.com/foo/bar?ref=something",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git://github.com/foo/bar#something",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git@github.com:foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git@github.com:foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git@github.com:foo/bar.git/",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git@github.com:foo/bar.git?ref=something",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git@github.com:foo/bar.git#something",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo",
+		},
+		{
+			url: "https://www.github.com/foo/bar",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git+ssh://git@github.com:foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "scm:git:https://github.com/foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://gitlab.com/foo/bar",
+			wantRepo: &types.Repository{
+				Name: "gitlab.com/foo/bar",
+			},
+		},
+		{
+			url: "git://gitlab.com/foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "gitlab.com/foo/bar",
+			},
+		},
+		{
+			url: "git@gitlab.com:foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "gitlab.com/foo/bar",
+			},
+		},
+		{
+			url: "https://gitlab.com/foo/bar/baz",
+			wantRepo: &types.Repository{
+				Name: "gitlab.com/foo/bar/baz",
+			},
+		},
+		{
+			url: "https://gitlab.com/foo/bar/baz/-/tree/main/qux",
+			wantRepo: &types.Repository{
+				Name:    "gitlab.com/foo/bar/baz",
+				Subpath: "qux",
+			},
+		},
+		{
+			url: "https://gitlab.com/foo",
+		},
+		{
+			url: "https://bitbucket.org/foo/bar/src/main/baz",
+			wantRepo: &types.Repository{
+				Name:    "bitbucket.org/foo/bar",
+				Subpath: "baz",
+			},
+		},
+		{
+			url: "git@bitbucket.org:foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "bitbucket.org/foo/bar",
+			},
+		},
+		{
+			url: "https://git.example.com/foo/bar/baz.git",
+			wantRepo: &types.Repository{
+				Name: "git.example.com/foo/bar/baz",
+			},
+		},
+		{
+			url: "git+https://github.com/foo/mono.git#packages/bar",
+			wantRepo: &types.Repository{
+				Name:    "github.com/foo/mono",
+				Subpath: "packages/bar",
+			},
+		},
+		{
+			url: "https://github.com/foo/mono/tree/main/packages/bar/",
+			wantRepo: &types.Repository{
+				Name:    "github.com/foo/mono",
+				Subpath: "packages/bar",
+			},
+		},
+		{
+			url: "git+https://github.com/foo/bar.git#v1.0.0",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git+https://github.com/foo/bar.git#egg=bar&subdirectory=baz/qux",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "url=https://github.com/foo/bar,connection=scm:git:https://github.com/foo/bar.git",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "git+https://github.com/foo/bar.git@v1.0.0#egg=bar",
+			wantRepo: &types.Repository{
+				Name: "github.com/foo/bar",
+			},
+		},
+		{
+			url: "https://notgithub.com/foo/bar",
+		},
+		{
+			url: "github.com",
+		},
+	}
+	if err := AddHost("git.example.com", PlatformGitLab); err != nil {
+		t.Fatalf("unexpected error adding host: %s", err)
+	}
+	for _, tc := range testCases {
+		gotRepo := ToRepository(tc.url)
+
+		if diff := cmp.Diff(gotRepo, tc.wantRepo); diff != "" {
+			t.Errorf("unexpected repository:\n%s", diff)
+		}
+	}
+}
+
+func TestPlatformOf(t *testing.T) {
+	if err := AddHost("GitLab.Example.org", PlatformGitLab); err != nil {
+		t.Fatalf("unexpected error adding host: %s", err)
+	}
+	testCases := map[string]Platform{
+		"github.com/foo/bar":         PlatformGitHub,
+		"gitlab.com/foo/bar/baz":     PlatformGitLab,
+		"bitbucket.org/foo/bar":      PlatformBitbucket,
+		"gitlab.example.org/foo/bar": PlatformGitLab,
+		"example.com/foo/bar":        "",
+	}
+	for repository, wantPlatform := range testCases {
+		if gotPlatform := PlatformOf(repository); gotPlatform != wantPlatform {
+			t.Errorf("unexpected platform for %s; wanted %q but got %q", repository, wantPlatform, gotPlatform)
+		}
+	}
+}
+
+func TestAddHostInvalid(t *testing.T) {
+	if err := AddHost("git.example.com", "svn"); err == nil {
+		t.Errorf("expected error adding a host with an unsupported platform")
+	}
+	if err := AddHost("", PlatformGitHub); err == nil {
+		t.Errorf("expected error adding an empty host")
+	}
+}